@@ -0,0 +1,211 @@
+package adapter
+
+import (
+	"encoding/binary"
+	"hash"
+	"math/bits"
+)
+
+// BLAKE3 256 位（无 key、非派生模式）的纯 Go 实现
+
+const (
+	blake3BlockLen = 64
+	blake3ChunkLen = 1024
+	blake3OutLen   = 32
+
+	blake3ChunkStart = 1 << 0
+	blake3ChunkEnd   = 1 << 1
+	blake3Parent     = 1 << 2
+	blake3Root       = 1 << 3
+)
+
+var blake3IV = [8]uint32{
+	0x6A09E667, 0xBB67AE85, 0x3C6EF372, 0xA54FF53A,
+	0x510E527F, 0x9B05688C, 0x1F83D9AB, 0x5BE0CD19,
+}
+
+var blake3Permutation = [16]int{2, 6, 3, 10, 7, 0, 4, 13, 1, 11, 12, 5, 9, 14, 15, 8}
+
+func blake3G(s *[16]uint32, a, b, c, d int, mx, my uint32) {
+	s[a] += s[b] + mx
+	s[d] = bits.RotateLeft32(s[d]^s[a], -16)
+	s[c] += s[d]
+	s[b] = bits.RotateLeft32(s[b]^s[c], -12)
+	s[a] += s[b] + my
+	s[d] = bits.RotateLeft32(s[d]^s[a], -8)
+	s[c] += s[d]
+	s[b] = bits.RotateLeft32(s[b]^s[c], -7)
+}
+
+func blake3Compress(cv *[8]uint32, block *[16]uint32, counter uint64, blockLen uint32, flags uint32) [16]uint32 {
+	s := [16]uint32{
+		cv[0], cv[1], cv[2], cv[3], cv[4], cv[5], cv[6], cv[7],
+		blake3IV[0], blake3IV[1], blake3IV[2], blake3IV[3],
+		uint32(counter), uint32(counter >> 32), blockLen, flags,
+	}
+	m := *block
+	for round := range 7 {
+		blake3G(&s, 0, 4, 8, 12, m[0], m[1])
+		blake3G(&s, 1, 5, 9, 13, m[2], m[3])
+		blake3G(&s, 2, 6, 10, 14, m[4], m[5])
+		blake3G(&s, 3, 7, 11, 15, m[6], m[7])
+		blake3G(&s, 0, 5, 10, 15, m[8], m[9])
+		blake3G(&s, 1, 6, 11, 12, m[10], m[11])
+		blake3G(&s, 2, 7, 8, 13, m[12], m[13])
+		blake3G(&s, 3, 4, 9, 14, m[14], m[15])
+		if round < 6 {
+			var p [16]uint32
+			for i, j := range blake3Permutation {
+				p[i] = m[j]
+			}
+			m = p
+		}
+	}
+	for i := range 8 {
+		s[i] ^= s[i+8]
+		s[i+8] ^= cv[i]
+	}
+	return s
+}
+
+func blake3Words(p []byte) (words [16]uint32) {
+	var block [blake3BlockLen]byte
+	copy(block[:], p)
+	for i := range words {
+		words[i] = binary.LittleEndian.Uint32(block[4*i:])
+	}
+	return words
+}
+
+// blake3Output 是尚未压缩的最后一次压缩输入，根节点需要额外的 ROOT 标志
+type blake3Output struct {
+	cv       [8]uint32
+	block    [16]uint32
+	counter  uint64
+	blockLen uint32
+	flags    uint32
+}
+
+func (o *blake3Output) chainingValue() (cv [8]uint32) {
+	s := blake3Compress(&o.cv, &o.block, o.counter, o.blockLen, o.flags)
+	copy(cv[:], s[:8])
+	return cv
+}
+
+func (o *blake3Output) rootBytes(b []byte) []byte {
+	s := blake3Compress(&o.cv, &o.block, 0, o.blockLen, o.flags|blake3Root)
+	for _, w := range s[:blake3OutLen/4] {
+		b = binary.LittleEndian.AppendUint32(b, w)
+	}
+	return b
+}
+
+func blake3ParentOutput(left, right [8]uint32) blake3Output {
+	o := blake3Output{cv: blake3IV, blockLen: blake3BlockLen, flags: blake3Parent}
+	copy(o.block[:8], left[:])
+	copy(o.block[8:], right[:])
+	return o
+}
+
+type blake3Chunk struct {
+	cv      [8]uint32
+	counter uint64
+	block   [blake3BlockLen]byte
+	// block 中已缓冲的字节数
+	blockLen int
+	// 已压缩的块数
+	blocks int
+}
+
+func (c *blake3Chunk) len() int {
+	return c.blocks*blake3BlockLen + c.blockLen
+}
+
+func (c *blake3Chunk) startFlag() uint32 {
+	if c.blocks == 0 {
+		return blake3ChunkStart
+	}
+	return 0
+}
+
+func (c *blake3Chunk) update(p []byte) {
+	for len(p) > 0 {
+		if c.blockLen == blake3BlockLen {
+			words := blake3Words(c.block[:])
+			s := blake3Compress(&c.cv, &words, c.counter, blake3BlockLen, c.startFlag())
+			copy(c.cv[:], s[:8])
+			c.blocks++
+			c.blockLen = 0
+		}
+		n := copy(c.block[c.blockLen:], p)
+		c.blockLen += n
+		p = p[n:]
+	}
+}
+
+func (c *blake3Chunk) output() blake3Output {
+	return blake3Output{
+		cv:       c.cv,
+		block:    blake3Words(c.block[:c.blockLen]),
+		counter:  c.counter,
+		blockLen: uint32(c.blockLen),
+		flags:    c.startFlag() | blake3ChunkEnd,
+	}
+}
+
+type blake3 struct {
+	chunk blake3Chunk
+	// 已完成子树的 chaining value，按子树从大到小排列
+	stack [][8]uint32
+}
+
+// NewBlake3 创建 BLAKE3 256 位哈希
+func NewBlake3() hash.Hash {
+	b := &blake3{}
+	b.Reset()
+	return b
+}
+
+func (b *blake3) Write(p []byte) (int, error) {
+	n := len(p)
+	for len(p) > 0 {
+		// 只有在后面还有数据时才结束当前 chunk，最后一个 chunk 留给 Sum
+		if b.chunk.len() == blake3ChunkLen {
+			out := b.chunk.output()
+			b.pushChunk(out.chainingValue(), b.chunk.counter+1)
+			b.chunk = blake3Chunk{cv: blake3IV, counter: b.chunk.counter + 1}
+		}
+		m := min(blake3ChunkLen-b.chunk.len(), len(p))
+		b.chunk.update(p[:m])
+		p = p[m:]
+	}
+	return n, nil
+}
+
+// pushChunk 合并所有已完整的子树，total 为包括该 chunk 在内的 chunk 总数
+func (b *blake3) pushChunk(cv [8]uint32, total uint64) {
+	for total&1 == 0 {
+		left := b.stack[len(b.stack)-1]
+		b.stack = b.stack[:len(b.stack)-1]
+		parent := blake3ParentOutput(left, cv)
+		cv = parent.chainingValue()
+		total >>= 1
+	}
+	b.stack = append(b.stack, cv)
+}
+
+func (b *blake3) Sum(in []byte) []byte {
+	out := b.chunk.output()
+	for i := len(b.stack) - 1; i >= 0; i-- {
+		out = blake3ParentOutput(b.stack[i], out.chainingValue())
+	}
+	return out.rootBytes(in)
+}
+
+func (b *blake3) Reset() {
+	b.chunk = blake3Chunk{cv: blake3IV}
+	b.stack = b.stack[:0]
+}
+
+func (b *blake3) Size() int      { return blake3OutLen }
+func (b *blake3) BlockSize() int { return blake3BlockLen }
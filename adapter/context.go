@@ -2,27 +2,27 @@ package adapter
 
 import (
 	"context"
-	"time"
+	"sync"
 
 	driverexports "github.com/OpenListTeam/openlist-wasi-plugin-driver/binding/openlist/plugin-driver/exports"
 
 	"go.bytecodealliance.org/cm"
 )
 
+// WarpCancellable 将宿主的 cancellable 转换为 context
+// 在返回的 CancelFunc 调用之前，cancellable 的 pollable 一直注册在 reactor 中，
+// 即使驱动阻塞在 channel、计算或其他库的 poll 上，宿主取消后也会在 reactorIdleMax 内取消返回的 context
 func WarpCancellable(pctx cm.Rep) (context.Context, context.CancelFunc) {
 	ctx, cancel := context.WithCancel(context.Background())
 	poll := cm.Reinterpret[driverexports.Cancellable]((uint32)(pctx)).Subscribe()
+	id := defaultReactor.register(poll, cancel)
+	var once sync.Once
 	cancelDrop := func() {
 		cancel()
-		poll.ResourceDrop()
+		once.Do(func() {
+			defaultReactor.unregister(id)
+			poll.ResourceDrop()
+		})
 	}
-	go func() {
-		// NOTE: 使用Block会导致后续调度卡死，可能是tinygo的缺陷
-		// poll.Block()
-		for !poll.Ready() && ctx.Err() == nil {
-			time.Sleep(time.Millisecond * 200)
-		}
-		cancelDrop()
-	}()
 	return ctx, cancelDrop
 }
@@ -0,0 +1,195 @@
+package adapter
+
+import (
+	"context"
+	"net"
+	"strconv"
+	"sync"
+
+	"github.com/OpenListTeam/openlist-wasi-plugin-driver/binding/wasi/io/poll"
+	instancenetwork "github.com/OpenListTeam/openlist-wasi-plugin-driver/binding/wasi/sockets/instance-network"
+	ipnamelookup "github.com/OpenListTeam/openlist-wasi-plugin-driver/binding/wasi/sockets/ip-name-lookup"
+	"github.com/OpenListTeam/openlist-wasi-plugin-driver/binding/wasi/sockets/network"
+	"go.bytecodealliance.org/cm"
+)
+
+var (
+	instanceNetworkOnce sync.Once
+	instanceNetwork     network.Network
+)
+
+// defaultNetwork 返回宿主提供的默认 network 资源，首次使用时获取并一直持有
+func defaultNetwork() network.Network {
+	instanceNetworkOnce.Do(func() {
+		instanceNetwork = instancenetwork.InstanceNetwork()
+	})
+	return instanceNetwork
+}
+
+// SocketError 宿主返回的 wasi:sockets error-code
+type SocketError struct {
+	Code network.ErrorCode
+}
+
+func (e *SocketError) Error() string {
+	return "wasi sockets: " + e.Code.String()
+}
+
+// Timeout 实现 net.Error
+func (e *SocketError) Timeout() bool {
+	return e.Code == network.ErrorCodeTimeout
+}
+
+// Temporary 实现 net.Error
+func (e *SocketError) Temporary() bool {
+	return e.Code == network.ErrorCodeTimeout || e.Code == network.ErrorCodeTemporaryResolverFailure
+}
+
+// awaitSocket 反复调用 finish，返回 would-block 时等待 subscribe 得到的 pollable 就绪后重试
+// wasi:sockets 的异步操作（finish-connect、accept、resolve-next-address 等）都遵循这一模式，deadline 为 nil 时不限制
+func awaitSocket[T any](ctx context.Context, deadline *pollDeadline, subscribe func() poll.Pollable, finish func() (T, network.ErrorCode, bool)) (T, error) {
+	var pollable poll.Pollable
+	defer func() {
+		if pollable != 0 {
+			pollable.ResourceDrop()
+		}
+	}()
+
+	for {
+		v, code, isErr := finish()
+		if !isErr {
+			return v, nil
+		}
+		if code != network.ErrorCodeWouldBlock {
+			return v, &SocketError{Code: code}
+		}
+		if pollable == 0 {
+			pollable = subscribe()
+		}
+		if err := waitDeadline(ctx, pollable, deadline); err != nil {
+			return v, err
+		}
+	}
+}
+
+// LookupIP 通过 wasi:sockets/ip-name-lookup 解析主机名，host 为 IP 字面量时直接返回
+// 结果按宿主给出的连接优先级排序，ctx 取消时中断等待
+func LookupIP(ctx context.Context, host string) ([]net.IP, error) {
+	if ip := net.ParseIP(host); ip != nil {
+		return []net.IP{ip}, nil
+	}
+
+	stream, code, isErr := ipnamelookup.ResolveAddresses(defaultNetwork(), host).Result()
+	if isErr {
+		return nil, dnsError(host, code)
+	}
+	defer stream.ResourceDrop()
+
+	var ips []net.IP
+	// pollable 是 stream 的子资源，在 awaitSocket 返回时已释放
+	for {
+		addr, err := awaitSocket(ctx, nil, stream.Subscribe, func() (cm.Option[network.IPAddress], network.ErrorCode, bool) {
+			return stream.ResolveNextAddress().Result()
+		})
+		if err != nil {
+			if serr, ok := err.(*SocketError); ok {
+				return nil, dnsError(host, serr.Code)
+			}
+			return nil, &net.DNSError{Err: err.Error(), Name: host, IsTimeout: ctx.Err() == context.DeadlineExceeded}
+		}
+		some := addr.Some()
+		if some == nil {
+			break
+		}
+		ips = append(ips, fromIPAddress(*some))
+	}
+	if len(ips) == 0 {
+		return nil, dnsError(host, network.ErrorCodeNameUnresolvable)
+	}
+	return ips, nil
+}
+
+// LookupHost 解析主机名，返回字符串形式的地址
+func LookupHost(ctx context.Context, host string) ([]string, error) {
+	ips, err := LookupIP(ctx, host)
+	if err != nil {
+		return nil, err
+	}
+	addrs := make([]string, len(ips))
+	for i, ip := range ips {
+		addrs[i] = ip.String()
+	}
+	return addrs, nil
+}
+
+func dnsError(host string, code network.ErrorCode) error {
+	err := &net.DNSError{
+		Err:         code.String(),
+		Name:        host,
+		IsTimeout:   code == network.ErrorCodeTimeout,
+		IsTemporary: code == network.ErrorCodeTemporaryResolverFailure,
+	}
+	if code == network.ErrorCodeNameUnresolvable {
+		// 与 net 包保持一致，便于按错误文本判断
+		err.Err = "no such host"
+		err.IsNotFound = true
+	}
+	return err
+}
+
+func fromIPAddress(addr network.IPAddress) net.IP {
+	if v4 := addr.IPv4(); v4 != nil {
+		return net.IPv4(v4[0], v4[1], v4[2], v4[3])
+	}
+	v6 := addr.IPv6()
+	ip := make(net.IP, net.IPv6len)
+	for i, seg := range v6 {
+		ip[i*2] = byte(seg >> 8)
+		ip[i*2+1] = byte(seg)
+	}
+	return ip
+}
+
+func fromSocketAddress(addr network.IPSocketAddress) (net.IP, int) {
+	if v4 := addr.IPv4(); v4 != nil {
+		return fromIPAddress(network.IPAddressIPv4(v4.Address)), int(v4.Port)
+	}
+	v6 := addr.IPv6()
+	return fromIPAddress(network.IPAddressIPv6(v6.Address)), int(v6.Port)
+}
+
+// toSocketAddress 将 IP 与端口转换为 wasi 的套接字地址，IPv4 地址（含映射形式）使用 ipv4
+// ip 为 nil 或长度无效时按 IPv4 全零地址处理
+func toSocketAddress(ip net.IP, port int) (network.IPSocketAddress, network.IPAddressFamily) {
+	if ip.To16() == nil {
+		ip = net.IPv4zero
+	}
+	if v4 := ip.To4(); v4 != nil {
+		return network.IPSocketAddressIPv4(network.IPv4SocketAddress{
+			Port:    uint16(port),
+			Address: network.IPv4Address{v4[0], v4[1], v4[2], v4[3]},
+		}), network.IPAddressFamilyIPv4
+	}
+	var addr network.IPv6Address
+	ip = ip.To16()
+	for i := range addr {
+		addr[i] = uint16(ip[i*2])<<8 | uint16(ip[i*2+1])
+	}
+	return network.IPSocketAddressIPv6(network.IPv6SocketAddress{
+		Port:    uint16(port),
+		Address: addr,
+	}), network.IPAddressFamilyIPv6
+}
+
+// splitHostPort 拆分 address 并解析端口号，仅支持数字端口
+func splitHostPort(address string) (string, int, error) {
+	host, portStr, err := net.SplitHostPort(address)
+	if err != nil {
+		return "", 0, err
+	}
+	port, err := strconv.ParseUint(portStr, 10, 16)
+	if err != nil {
+		return "", 0, &net.AddrError{Err: "invalid port", Addr: address}
+	}
+	return host, int(port), nil
+}
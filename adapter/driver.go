@@ -12,6 +12,16 @@ import (
 type ResultOptionObject = cm.Result[driverexports.OptionObjectShape, cm.Option[driverexports.Object], driverexports.DriverErrors]
 type ResultObject = cm.Result[driverexports.ObjectShape, driverexports.Object, driverexports.DriverErrors]
 type ResultObjects = cm.Result[driverexports.DriverErrorsShape, cm.List[driverexports.Object], driverexports.DriverErrors]
+type ResultListPage = cm.Result[driverexports.ListPageShape, driverexports.ListPage, driverexports.DriverErrors]
+type ResultStorageDetails = cm.Result[driverexports.StorageDetailsShape, driverexports.StorageDetails, driverexports.DriverErrors]
+type ResultOfflineTask = cm.Result[driverexports.OfflineTaskShape, driverexports.OfflineTask, driverexports.DriverErrors]
+type ResultArchiveMeta = cm.Result[driverexports.ArchiveMetaShape, driverexports.ArchiveMeta, driverexports.DriverErrors]
+type ResultTrashedObjects = cm.Result[driverexports.DriverErrorsShape, cm.List[driverexports.TrashedObject], driverexports.DriverErrors]
+type ResultFileVersions = cm.Result[driverexports.DriverErrorsShape, cm.List[driverexports.FileVersion], driverexports.DriverErrors]
+type ResultShareInfo = cm.Result[driverexports.ShareInfoShape, driverexports.ShareInfo, driverexports.DriverErrors]
+type ResultUploadLinkInfo = cm.Result[driverexports.UploadLinkInfoShape, driverexports.UploadLinkInfo, driverexports.DriverErrors]
+type ResultShareInfos = cm.Result[driverexports.DriverErrorsShape, cm.List[driverexports.ShareInfo], driverexports.DriverErrors]
+type ResultBytes = cm.Result[driverexports.DriverErrorsShape, cm.List[uint8], driverexports.DriverErrors]
 type Result = cm.Result[driverexports.DriverErrors, struct{}, driverexports.DriverErrors]
 
 //go:inline
@@ -94,6 +104,24 @@ func ReturnErrObjects(err error) (result ResultObjects) {
 	return cm.Err[ResultObjects](drivertypes.DriverErrorsGeneric(err.Error()))
 }
 
+// result<list-page, err-code>
+//
+//go:inline
+func ReturnOkListPage(objs []driverexports.Object, nextCursor string) (result ResultListPage) {
+	page := driverexports.ListPage{Objects: cm.ToList(objs)}
+	if nextCursor != "" {
+		page.NextCursor = cm.Some(nextCursor)
+	}
+	return cm.OK[ResultListPage](page)
+}
+
+// result<list-page, err-code>
+//
+//go:inline
+func ReturnErrListPage(err error) (result ResultListPage) {
+	return cm.Err[ResultListPage](drivertypes.DriverErrorsGeneric(err.Error()))
+}
+
 // result<_, err-code>
 //
 //go:inline
@@ -26,6 +26,8 @@ func ErrorToDriverError(err error) driverexports.DriverErrors {
 		return drivertypes.DriverErrorsInvalidHandle()
 	case errors.Is(err, ErrNotImplemented):
 		return drivertypes.DriverErrorsNotImplemented()
+	case errors.Is(err, ErrNotSupport):
+		return drivertypes.DriverErrorsNotSupport()
 	case errors.Is(err, ErrNotFound):
 		return drivertypes.DriverErrorsNotFound()
 	case errors.Is(err, ErrNotFolder):
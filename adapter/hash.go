@@ -0,0 +1,264 @@
+package adapter
+
+import (
+	"crypto/md5"
+	"crypto/sha1"
+	"crypto/sha256"
+	"encoding"
+	"encoding/base64"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"hash"
+	"hash/crc32"
+	"hash/crc64"
+	"io"
+	"strings"
+
+	drivertypes "github.com/OpenListTeam/openlist-wasi-plugin-driver/binding/openlist/plugin-driver/types"
+)
+
+// NewHash 创建指定算法的 hash.Hash，size 为内容总大小，仅 gcid 需要
+// 所有实现均为纯 Go，可在 tinygo 下使用；Sum 的结果按大端字节序输出
+func NewHash(alg drivertypes.HashAlg, size int64) (hash.Hash, error) {
+	switch {
+	case alg.Md5():
+		return md5.New(), nil
+	case alg.Sha1():
+		return sha1.New(), nil
+	case alg.Sha256():
+		return sha256.New(), nil
+	case alg.Gcid():
+		return NewGcid(size), nil
+	case alg.Crc32c():
+		return crc32.New(crc32.MakeTable(crc32.Castagnoli)), nil
+	case alg.Crc64Ecma():
+		return crc64.New(crc64.MakeTable(crc64.ECMA)), nil
+	case alg.QuickXor():
+		return NewQuickXor(), nil
+	case alg.Dropbox():
+		return NewDropbox(), nil
+	case alg.Xxh3():
+		return NewXxh3(), nil
+	case alg.Blake3():
+		return NewBlake3(), nil
+	}
+	return nil, fmt.Errorf("%w: hash %s", ErrNotSupport, HashAlgName(alg))
+}
+
+// hashAlgOtherPrefix 是 other(string) 在文本形式中的前缀，避免与已知算法名称冲突
+const hashAlgOtherPrefix = "other:"
+
+var hashAlgCases = [...]func() drivertypes.HashAlg{
+	drivertypes.HashAlgMd5,
+	drivertypes.HashAlgSha1,
+	drivertypes.HashAlgSha256,
+	drivertypes.HashAlgGcid,
+	drivertypes.HashAlgCrc32c,
+	drivertypes.HashAlgCrc64Ecma,
+	drivertypes.HashAlgQuickXor,
+	drivertypes.HashAlgDropbox,
+	drivertypes.HashAlgXxh3,
+	drivertypes.HashAlgBlake3,
+}
+
+// HashAlgName 返回算法的文本形式，已知算法为名称，other(x) 为 "other:x"，可由 ParseHashAlg 还原
+func HashAlgName(alg drivertypes.HashAlg) string {
+	if other := alg.Other(); other != nil {
+		return hashAlgOtherPrefix + *other
+	}
+	return alg.String()
+}
+
+// ParseHashAlg 解析 HashAlgName 返回的文本，未知的名称按 other(name) 处理
+func ParseHashAlg(name string) (drivertypes.HashAlg, error) {
+	if other, ok := strings.CutPrefix(name, hashAlgOtherPrefix); ok {
+		return drivertypes.HashAlgOther(other), nil
+	}
+	if name == "" {
+		return drivertypes.HashAlg{}, errors.New("empty hash-alg")
+	}
+	for _, newAlg := range hashAlgCases {
+		if alg := newAlg(); alg.String() == name {
+			return alg, nil
+		}
+	}
+	return drivertypes.HashAlgOther(name), nil
+}
+
+// HashAlgKey 可比较的 HashAlg，用作 map 的键或判断两个算法是否相同
+// HashAlg 是 cm.Variant，直接比较会连同未使用的负载字节一起比较（来自宿主的内存不保证清零），
+// 因此只保留 tag 和 other 的名称
+type HashAlgKey struct {
+	tag   uint8
+	other string
+}
+
+// KeyOfHashAlg 返回 alg 的 HashAlgKey
+func KeyOfHashAlg(alg drivertypes.HashAlg) HashAlgKey {
+	key := HashAlgKey{tag: alg.Tag()}
+	if other := alg.Other(); other != nil {
+		key.other = *other
+	}
+	return key
+}
+
+// HashAlgEqual 判断 a 和 b 是否表示同一种算法，不能对 HashAlg 直接使用 ==
+func HashAlgEqual(a, b drivertypes.HashAlg) bool {
+	return KeyOfHashAlg(a) == KeyOfHashAlg(b)
+}
+
+// MultiHasher 在一次读取中同时计算多种哈希，不需要宿主缓存文件
+type MultiHasher struct {
+	algs   []drivertypes.HashAlg
+	hashes []hash.Hash
+	writer io.Writer
+}
+
+// NewMultiHasher 创建 MultiHasher，size 为内容总大小
+func NewMultiHasher(algs []drivertypes.HashAlg, size int64) (*MultiHasher, error) {
+	m := &MultiHasher{
+		algs:   algs,
+		hashes: make([]hash.Hash, len(algs)),
+	}
+	writers := make([]io.Writer, len(algs))
+	for i, alg := range algs {
+		h, err := NewHash(alg, size)
+		if err != nil {
+			return nil, err
+		}
+		m.hashes[i], writers[i] = h, h
+	}
+	m.writer = io.MultiWriter(writers...)
+	return m, nil
+}
+
+func (m *MultiHasher) Write(p []byte) (int, error) {
+	return m.writer.Write(p)
+}
+
+// TeeReader 返回一个 Reader，读取 r 的同时计算哈希
+func (m *MultiHasher) TeeReader(r io.Reader) io.Reader {
+	if len(m.hashes) == 0 {
+		return r
+	}
+	return io.TeeReader(r, m.writer)
+}
+
+// Sum 返回各算法的哈希值，顺序与创建时的 algs 相同，Val 均为小写十六进制
+// 只有在内容完整读取后结果才有意义
+func (m *MultiHasher) Sum() []drivertypes.HashInfo {
+	if len(m.hashes) == 0 {
+		return nil
+	}
+	infos := make([]drivertypes.HashInfo, len(m.hashes))
+	for i, h := range m.hashes {
+		infos[i] = drivertypes.HashInfo{Alg: m.algs[i], Val: hex.EncodeToString(h.Sum(nil))}
+	}
+	return infos
+}
+
+// HashValBase64 将十六进制的 HashInfo.Val 转换为 base64，OneDrive 的 quickXorHash 使用该形式
+func HashValBase64(val string) (string, error) {
+	sum, err := hex.DecodeString(val)
+	if err != nil {
+		return "", err
+	}
+	return base64.StdEncoding.EncodeToString(sum), nil
+}
+
+// HashingStreams 调用 Streams 并在读取时计算哈希
+// 读取完成后通过 MultiHasher.Sum 获取结果，可用于最终的提交请求
+func (us *UploadRequest) HashingStreams(algs []drivertypes.HashAlg) (io.ReadCloser, *MultiHasher, error) {
+	m, err := NewMultiHasher(algs, us.Object.Size)
+	if err != nil {
+		return nil, nil, err
+	}
+	stream, err := us.Streams()
+	if err != nil {
+		return nil, nil, err
+	}
+	return &hashingReader{Reader: m.TeeReader(stream), Closer: stream}, m, nil
+}
+
+type hashingReader struct {
+	io.Reader
+	io.Closer
+}
+
+// NewGcid 创建迅雷 GCID 哈希，size 为内容总大小
+// 按块计算 sha1，再对所有块的 sha1 计算 sha1
+// 块大小从 256KiB 开始，块数量超过 512 时翻倍，最大 2MiB
+func NewGcid(size int64) hash.Hash {
+	blockSize := int64(0x40000)
+	for size/blockSize > 0x200 && blockSize < 0x200000 {
+		blockSize <<= 1
+	}
+	return newBlockHash(sha1.New, blockSize)
+}
+
+// NewDropbox 创建 Dropbox content hash
+// 按 4MiB 分块计算 sha256，再对所有块的 sha256 计算 sha256
+func NewDropbox() hash.Hash {
+	return newBlockHash(sha256.New, 4<<20)
+}
+
+// blockHash 对每个块计算哈希，再对块哈希的拼接计算同一种哈希
+type blockHash struct {
+	new       func() hash.Hash
+	hash      hash.Hash
+	block     hash.Hash
+	blockSize int64
+	remain    int64
+}
+
+func newBlockHash(new func() hash.Hash, blockSize int64) *blockHash {
+	return &blockHash{
+		new:       new,
+		hash:      new(),
+		block:     new(),
+		blockSize: blockSize,
+		remain:    blockSize,
+	}
+}
+
+func (b *blockHash) Write(p []byte) (int, error) {
+	n := len(p)
+	for len(p) > 0 {
+		m := min(int64(len(p)), b.remain)
+		b.block.Write(p[:m])
+		p, b.remain = p[m:], b.remain-m
+		if b.remain == 0 {
+			b.hash.Write(b.block.Sum(nil))
+			b.block.Reset()
+			b.remain = b.blockSize
+		}
+	}
+	return n, nil
+}
+
+func (b *blockHash) Sum(in []byte) []byte {
+	h := b.hash
+	if b.remain < b.blockSize {
+		// 复制外层哈希的内部状态，Sum 时不影响后续写入
+		state, err := h.(encoding.BinaryMarshaler).MarshalBinary()
+		if err != nil {
+			panic(err)
+		}
+		h = b.new()
+		if err := h.(encoding.BinaryUnmarshaler).UnmarshalBinary(state); err != nil {
+			panic(err)
+		}
+		h.Write(b.block.Sum(nil))
+	}
+	return h.Sum(in)
+}
+
+func (b *blockHash) Reset() {
+	b.hash.Reset()
+	b.block.Reset()
+	b.remain = b.blockSize
+}
+
+func (b *blockHash) Size() int      { return b.hash.Size() }
+func (b *blockHash) BlockSize() int { return b.hash.BlockSize() }
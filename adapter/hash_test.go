@@ -0,0 +1,297 @@
+package adapter
+
+import (
+	"encoding/hex"
+	"hash"
+	"io"
+	"strings"
+	"testing"
+
+	drivertypes "github.com/OpenListTeam/openlist-wasi-plugin-driver/binding/openlist/plugin-driver/types"
+	"go.bytecodealliance.org/cm"
+)
+
+func TestHashAlgKey(t *testing.T) {
+	// 模拟宿主传入的 md5（tag 0）：负载字节未清零
+	dirty := cm.New[drivertypes.HashAlg](uint8(0), "garbage")
+	if !HashAlgEqual(dirty, drivertypes.HashAlgMd5()) {
+		t.Error("md5 with dirty payload != HashAlgMd5()")
+	}
+	if HashAlgEqual(drivertypes.HashAlgMd5(), drivertypes.HashAlgSha1()) {
+		t.Error("md5 == sha1")
+	}
+	if !HashAlgEqual(drivertypes.HashAlgOther("pikpak"), drivertypes.HashAlgOther("pikpak")) {
+		t.Error("other(pikpak) != other(pikpak)")
+	}
+	if HashAlgEqual(drivertypes.HashAlgOther("pikpak"), drivertypes.HashAlgOther("115")) {
+		t.Error("other(pikpak) == other(115)")
+	}
+
+	known := map[HashAlgKey]string{KeyOfHashAlg(dirty): "d41d8cd98f00b204e9800998ecf8427e"}
+	if _, ok := known[KeyOfHashAlg(drivertypes.HashAlgMd5())]; !ok {
+		t.Error("lookup with HashAlgMd5() missed")
+	}
+}
+
+func TestHashAlgName(t *testing.T) {
+	tests := []struct {
+		alg  drivertypes.HashAlg
+		name string
+	}{
+		{drivertypes.HashAlgMd5(), "md5"},
+		{drivertypes.HashAlgCrc64Ecma(), "crc64-ecma"},
+		{drivertypes.HashAlgBlake3(), "blake3"},
+		{drivertypes.HashAlgOther("pikpak"), "other:pikpak"},
+		// 与已知名称相同的 other 不会被还原为已知算法
+		{drivertypes.HashAlgOther("md5"), "other:md5"},
+		{drivertypes.HashAlgOther(""), "other:"},
+	}
+	for _, tt := range tests {
+		if name := HashAlgName(tt.alg); name != tt.name {
+			t.Errorf("HashAlgName(%v) = %q, want %q", tt.alg, name, tt.name)
+		}
+		alg, err := ParseHashAlg(tt.name)
+		if err != nil {
+			t.Errorf("ParseHashAlg(%q) error = %v", tt.name, err)
+			continue
+		}
+		if !HashAlgEqual(alg, tt.alg) {
+			t.Errorf("ParseHashAlg(%q) = %q, want %q", tt.name, HashAlgName(alg), tt.name)
+		}
+	}
+
+	if alg, _ := ParseHashAlg("sha512"); !HashAlgEqual(alg, drivertypes.HashAlgOther("sha512")) {
+		t.Errorf("ParseHashAlg(sha512) = %q, want other:sha512", HashAlgName(alg))
+	}
+	if _, err := ParseHashAlg(""); err == nil {
+		t.Error("ParseHashAlg(\"\") succeeded")
+	}
+}
+
+// hashVector 已知答案，in 为空时输入为 hashInput(n)
+type hashVector struct {
+	in   string
+	n    int
+	want string
+}
+
+func (v hashVector) input() []byte {
+	if v.in != "" {
+		return []byte(v.in)
+	}
+	return hashInput(v.n)
+}
+
+// hashInput 返回 n 字节的 0, 1, ..., 250, 0, 1, ...，与 BLAKE3 官方测试向量的输入相同
+func hashInput(n int) []byte {
+	b := make([]byte, n)
+	for i := range b {
+		b[i] = byte(i % 251)
+	}
+	return b
+}
+
+// 手写实现的已知答案，覆盖各算法的短输入分支、块边界与多块输入
+// xxh3、blake3 由参考实现生成，quickXor 由按位实现的规范生成，gcid、dropbox 由逐块计算的 sha1、sha256 生成
+var hashKATs = []struct {
+	alg     func() drivertypes.HashAlg
+	vectors []hashVector
+}{
+	{
+		alg: drivertypes.HashAlgXxh3,
+		vectors: []hashVector{
+			{in: "abc", want: "78af5f94892f3950"},
+			{n: 0, want: "2d06800538d394c2"},
+			{n: 1, want: "c44bdff4074eecdb"},
+			{n: 3, want: "5f4299fc161c9cbb"},
+			{n: 4, want: "60dab036a58211f2"},
+			{n: 8, want: "3a1c2d7c85af88f8"},
+			{n: 9, want: "e9612598145bb9dc"},
+			{n: 16, want: "8355e3a6f61770db"},
+			{n: 17, want: "9ef341a99de37328"},
+			{n: 128, want: "85c6174c7ff4c46b"},
+			{n: 129, want: "ec7642b431ba3e5a"},
+			{n: 240, want: "375a384d957fe865"},
+			{n: 241, want: "02e8cd95421c6d02"},
+			{n: 1023, want: "d3d91d80ac495685"},
+			{n: 1024, want: "e5d78bafa45b2aa5"},
+			{n: 1025, want: "e95c42288f28186e"},
+			{n: 2048, want: "25339063db861586"},
+			{n: 2049, want: "6c9600c0e506e2ae"},
+			{n: 4096, want: "7135ffa504f1bc71"},
+			{n: 100000, want: "42c23aeead96750d"},
+		},
+	},
+	{
+		alg: drivertypes.HashAlgBlake3,
+		vectors: []hashVector{
+			{in: "abc", want: "6437b3ac38465133ffb63b75273a8db548c558465d79db03fd359c6cd5bd9d85"},
+			{n: 0, want: "af1349b9f5f9a1a6a0404dea36dcc9499bcb25c9adc112b7cc9a93cae41f3262"},
+			{n: 1, want: "2d3adedff11b61f14c886e35afa036736dcd87a74d27b5c1510225d0f592e213"},
+			{n: 63, want: "e9bc37a594daad83be9470df7f7b3798297c3d834ce80ba85d6e207627b7db7b"},
+			{n: 64, want: "4eed7141ea4a5cd4b788606bd23f46e212af9cacebacdc7d1f4c6dc7f2511b98"},
+			{n: 65, want: "de1e5fa0be70df6d2be8fffd0e99ceaa8eb6e8c93a63f2d8d1c30ecb6b263dee"},
+			{n: 128, want: "f17e570564b26578c33bb7f44643f539624b05df1a76c81f30acd548c44b45ef"},
+			{n: 129, want: "683aaae9f3c5ba37eaaf072aed0f9e30bac0865137bae68b1fde4ca2aebdcb12"},
+			{n: 240, want: "45e1a0dc23dbe51733d7269a3c0f519c2a63b0718835b2b537677eba734db0d8"},
+			{n: 1023, want: "10108970eeda3eb932baac1428c7a2163b0e924c9a9e25b35bba72b28f70bd11"},
+			{n: 1024, want: "42214739f095a406f3fc83deb889744ac00df831c10daa55189b5d121c855af7"},
+			{n: 1025, want: "d00278ae47eb27b34faecf67b4fe263f82d5412916c1ffd97c8cb7fb814b8444"},
+			{n: 2048, want: "e776b6028c7cd22a4d0ba182a8bf62205d2ef576467e838ed6f2529b85fba24a"},
+			{n: 2049, want: "5f4d72f40d7a5f82b15ca2b2e44b1de3c2ef86c426c95c1af0b6879522563030"},
+			{n: 3072, want: "b98cb0ff3623be03326b373de6b9095218513e64f1ee2edd2525c7ad1e5cffd2"},
+			{n: 3073, want: "7124b49501012f81cc7f11ca069ec9226cecb8a2c850cfe644e327d22d3e1cd3"},
+			{n: 4096, want: "015094013f57a5277b59d8475c0501042c0b642e531b0a1c8f58d2163229e969"},
+			{n: 4097, want: "9b4052b38f1c5fc8b1f9ff7ac7b27cd242487b3d890d15c96a1c25b8aa0fb995"},
+			{n: 8193, want: "bab6c09cb8ce8cf459261398d2e7aef35700bf488116ceb94a36d0f5f1b7bc3b"},
+			{n: 31745, want: "5c80ce0c3bbe9a6f432a1c6c2ccbde45923d23249386988a30f512d23919eb98"},
+			{n: 100000, want: "d93c23eedaf165a7e0be908ba86f1a7a520d568d2d13cde787c8580c5c72cc54"},
+		},
+	},
+	{
+		alg: drivertypes.HashAlgQuickXor,
+		vectors: []hashVector{
+			{in: "abc", want: "6110c31800000000000000000300000000000000"},
+			{n: 0, want: "0000000000000000000000000000000000000000"},
+			{n: 1, want: "0000000000000000000000000100000000000000"},
+			{n: 3, want: "0008800000000000000000000300000000000000"},
+			{n: 19, want: "e00990888644800218e000085b800216c0800638"},
+			{n: 20, want: "e00990888644a60218e000085c800216c0800638"},
+			{n: 21, want: "e00990888644a64219e000085d800216c0800638"},
+			{n: 128, want: "7d84a82dc878c32d0faf79166acb26a3509834b5"},
+			{n: 159, want: "ffe1062e59d08b4755b393849275a1127cf9221d"},
+			{n: 160, want: "ffe1062e59d08b4755b39384ad75a1127cf9c20e"},
+			{n: 161, want: "5fe1062e59d08b4755b39384ac75a1127cf9c20e"},
+			{n: 240, want: "5cb94cfa165aa44f456bf2d5f0808d9859b8b3c5"},
+			{n: 1024, want: "2b2461fbf8e0f15c75fe7b269f9e07c4f6dc839f"},
+			{n: 1025, want: "2b2461fbf8e0f15c61fe7b269e9e07c4f6dc839f"},
+			{n: 100000, want: "c392fec9711b4381a122d64e35b1b7cd5ea37525"},
+		},
+	},
+	{
+		alg: drivertypes.HashAlgGcid,
+		vectors: []hashVector{
+			{in: "abc", want: "0d3ced9bec10a777aec23ccc353a8c08a633045e"},
+			{n: 0, want: "da39a3ee5e6b4b0d3255bfef95601890afd80709"},
+			{n: 1, want: "7ab8dc8456c25f132551f157c77a1888ef918fac"},
+			{n: 1024, want: "5adc3b1409ab0e3d676f46a0bc38175ca9bdd19e"},
+			{n: 262143, want: "f3a2799d7afdd7af1c4c2851d1b329c5997bf8fa"},
+			{n: 262144, want: "f43c5d94b1f43cd4665dac585073ff87ff44ed68"},
+			{n: 262145, want: "b49c2f0058570f636c866ea282dd5cc9463416e8"},
+			{n: 524288, want: "8271a9efaebd4a042bde8223242ad4fd1f5911c7"},
+		},
+	},
+	{
+		alg: drivertypes.HashAlgDropbox,
+		vectors: []hashVector{
+			{in: "abc", want: "4f8b42c22dd3729b519ba6f68d2da7cc5b2d606d05daed5ad5128cc03e6c6358"},
+			{n: 0, want: "e3b0c44298fc1c149afbf4c8996fb92427ae41e4649b934ca495991b7852b855"},
+			{n: 1, want: "1406e05881e299367766d313e26c05564ec91bf721d31726bd6e46e60689539a"},
+			{n: 1024, want: "0b7db34d6857ac6d1a3e99833ca692a1112eb0d97ba041c8311cc3e265377ffc"},
+			{n: 4194303, want: "e1d05b9adf4293b7fec11b099ce74116fc06dfa733a9833be9166ab4c769da43"},
+			{n: 4194304, want: "b9654428408015906b44a00935b70af33830aa344b780b0eabd535a133150d04"},
+			{n: 4194305, want: "4a6cc0a344febaa07772e7c974834b2fb1d24594d4ba15f27c97a54699709f44"},
+		},
+	},
+}
+
+func TestHashKnownAnswers(t *testing.T) {
+	for _, tt := range hashKATs {
+		name := HashAlgName(tt.alg())
+		for _, v := range tt.vectors {
+			in := v.input()
+			newHash := func() hash.Hash {
+				h, err := NewHash(tt.alg(), int64(len(in)))
+				if err != nil {
+					t.Fatalf("NewHash(%s): %v", name, err)
+				}
+				return h
+			}
+
+			h := newHash()
+			h.Write(in)
+			if got := hex.EncodeToString(h.Sum(nil)); got != v.want {
+				t.Errorf("%s(%d bytes) = %s, want %s", name, len(in), got, v.want)
+				continue
+			}
+			if got := h.Sum([]byte("prefix")); string(got[:6]) != "prefix" || hex.EncodeToString(got[6:]) != v.want {
+				t.Errorf("%s(%d bytes): Sum did not append to its argument", name, len(in))
+			}
+			if h.Size() != len(v.want)/2 {
+				t.Errorf("%s: Size() = %d, want %d", name, h.Size(), len(v.want)/2)
+			}
+
+			// 分多次写入，中途的 Sum 不影响后续写入
+			for _, step := range []int{1, 7, 64, 1000} {
+				if len(in) > 4096 && step < 64 {
+					continue
+				}
+				h := newHash()
+				for i, p := 0, in; len(p) > 0; i++ {
+					n := min(step, len(p))
+					h.Write(p[:n])
+					p = p[n:]
+					if i%16 == 0 {
+						h.Sum(nil)
+					}
+				}
+				if got := hex.EncodeToString(h.Sum(nil)); got != v.want {
+					t.Errorf("%s(%d bytes) in %d byte writes = %s, want %s", name, len(in), step, got, v.want)
+				}
+			}
+
+			// Reset 后重新计算
+			h.Write([]byte("garbage"))
+			h.Reset()
+			h.Write(in)
+			if got := hex.EncodeToString(h.Sum(nil)); got != v.want {
+				t.Errorf("%s(%d bytes) after Reset = %s, want %s", name, len(in), got, v.want)
+			}
+		}
+	}
+}
+
+func TestGcidBlockSize(t *testing.T) {
+	tests := []struct {
+		size      int64
+		blockSize int64
+	}{
+		{0, 256 << 10},
+		{128 << 20, 256 << 10},
+		{128<<20 + 256<<10, 512 << 10},
+		{512 << 20, 1 << 20},
+		{1 << 30, 2 << 20},
+		{100 << 30, 2 << 20},
+	}
+	for _, tt := range tests {
+		if got := NewGcid(tt.size).(*blockHash).blockSize; got != tt.blockSize {
+			t.Errorf("NewGcid(%d) block size = %d, want %d", tt.size, got, tt.blockSize)
+		}
+	}
+}
+
+func TestMultiHasherSum(t *testing.T) {
+	algs := []drivertypes.HashAlg{drivertypes.HashAlgMd5(), drivertypes.HashAlgQuickXor()}
+	m, err := NewMultiHasher(algs, 3)
+	if err != nil {
+		t.Fatal(err)
+	}
+	io.Copy(m, strings.NewReader("abc"))
+	infos := m.Sum()
+	if len(infos) != 2 {
+		t.Fatalf("Sum() returned %d hashes, want 2", len(infos))
+	}
+	if infos[0].Val != "900150983cd24fb0d6963f7d28e17f72" {
+		t.Errorf("md5 = %s", infos[0].Val)
+	}
+	if infos[1].Val != "6110c31800000000000000000300000000000000" {
+		t.Errorf("quick-xor = %s", infos[1].Val)
+	}
+	// OneDrive 使用 base64 形式
+	if b64, err := HashValBase64(infos[1].Val); err != nil || b64 != "YRDDGAAAAAAAAAAAAwAAAAAAAAA=" {
+		t.Errorf("HashValBase64(quick-xor) = %q, %v", b64, err)
+	}
+	if _, err := HashValBase64("xyz"); err == nil {
+		t.Error("HashValBase64(xyz) succeeded")
+	}
+}
@@ -0,0 +1,28 @@
+package adapter
+
+import (
+	"fmt"
+	"net/http"
+
+	httptypes "github.com/OpenListTeam/openlist-wasi-plugin-driver/binding/wasi/http/types"
+	"go.bytecodealliance.org/cm"
+)
+
+// NewHeaders 将 http.Header 转换为宿主的 headers 资源
+// 返回值放入 link-info、upload-part-link 等记录后，所有权转移给宿主
+func NewHeaders(h http.Header) (httptypes.Headers, error) {
+	entries := make([]cm.Tuple[httptypes.FieldName, httptypes.FieldValue], 0, len(h))
+	for name, values := range h {
+		for _, value := range values {
+			entries = append(entries, cm.Tuple[httptypes.FieldName, httptypes.FieldValue]{
+				F0: httptypes.FieldName(name),
+				F1: httptypes.FieldValue(cm.ToList([]byte(value))),
+			})
+		}
+	}
+	fields, herr, isErr := httptypes.FieldsFromList(cm.ToList(entries)).Result()
+	if isErr {
+		return 0, fmt.Errorf("invalid headers: %s", herr)
+	}
+	return fields, nil
+}
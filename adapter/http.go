@@ -0,0 +1,451 @@
+package adapter
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/cookiejar"
+	"strconv"
+	"strings"
+	"time"
+
+	outgoinghandler "github.com/OpenListTeam/openlist-wasi-plugin-driver/binding/wasi/http/outgoing-handler"
+	httptypes "github.com/OpenListTeam/openlist-wasi-plugin-driver/binding/wasi/http/types"
+	"go.bytecodealliance.org/cm"
+)
+
+// DefaultMaxRedirects NewHTTPClient 默认允许的最大重定向次数，与 net/http 一致
+const DefaultMaxRedirects = 10
+
+// Transport 通过 wasi:http/outgoing-handler 发送请求的 http.RoundTripper
+// 请求与响应 body 均以 wasi 流传输，等待过程响应 req.Context() 的取消与截止时间
+// 在驱动方法内应使用 WarpCancellable 得到的 ctx 构造请求，宿主取消调用时请求随之中断
+type Transport struct {
+	// 建立连接的超时，零值使用宿主默认值
+	ConnectTimeout time.Duration
+	// 请求发送完成后等待响应首字节的超时
+	FirstByteTimeout time.Duration
+	// 接收响应时相邻两次数据之间的超时
+	BetweenBytesTimeout time.Duration
+}
+
+// DefaultTransport 使用宿主默认超时的 Transport
+var DefaultTransport http.RoundTripper = &Transport{}
+
+// HTTPClientOptions NewHTTPClient 的配置
+type HTTPClientOptions struct {
+	// 底层 Transport，为 nil 时使用 DefaultTransport
+	Transport http.RoundTripper
+	// 整个请求（含读取响应 body）的超时，零值表示不限制
+	Timeout time.Duration
+	// 最大重定向次数，零值使用 DefaultMaxRedirects，负数表示不跟随重定向
+	MaxRedirects int
+	// cookie 存储，为 nil 时创建新的 cookiejar
+	Jar http.CookieJar
+}
+
+// NewHTTPClient 创建基于 wasi:http 的 http.Client，支持超时、重定向与 cookie
+func NewHTTPClient(opts HTTPClientOptions) *http.Client {
+	transport := opts.Transport
+	if transport == nil {
+		transport = DefaultTransport
+	}
+	jar := opts.Jar
+	if jar == nil {
+		// 未指定 PublicSuffixList 时不会返回错误
+		jar, _ = cookiejar.New(nil)
+	}
+	return &http.Client{
+		Transport:     transport,
+		Timeout:       opts.Timeout,
+		Jar:           jar,
+		CheckRedirect: redirectPolicy(opts.MaxRedirects),
+	}
+}
+
+// redirectPolicy 返回 http.Client.CheckRedirect，maxRedirects 的含义同 HTTPClientOptions.MaxRedirects
+func redirectPolicy(maxRedirects int) func(req *http.Request, via []*http.Request) error {
+	if maxRedirects == 0 {
+		maxRedirects = DefaultMaxRedirects
+	}
+	return func(req *http.Request, via []*http.Request) error {
+		if maxRedirects < 0 {
+			return http.ErrUseLastResponse
+		}
+		if len(via) >= maxRedirects {
+			return fmt.Errorf("stopped after %d redirects", maxRedirects)
+		}
+		return nil
+	}
+}
+
+// HTTPError 宿主返回的 wasi:http error-code
+type HTTPError struct {
+	Code httptypes.ErrorCode
+}
+
+func (e *HTTPError) Error() string {
+	code := e.Code
+	switch {
+	case code.DNSError() != nil:
+		payload := code.DNSError()
+		if rcode := payload.Rcode.Some(); rcode != nil {
+			return "wasi http: DNS error: " + *rcode
+		}
+	case code.InternalError() != nil:
+		if msg := code.InternalError().Some(); msg != nil {
+			return "wasi http: internal error: " + *msg
+		}
+	}
+	return "wasi http: " + code.String()
+}
+
+// Timeout 实现 net.Error，超时类错误返回 true
+func (e *HTTPError) Timeout() bool {
+	code := e.Code
+	return code.DNSTimeout() || code.ConnectionTimeout() || code.ConnectionReadTimeout() ||
+		code.ConnectionWriteTimeout() || code.HTTPResponseTimeout()
+}
+
+// Temporary 实现 net.Error
+func (e *HTTPError) Temporary() bool {
+	return e.Timeout()
+}
+
+// 宿主禁止或由宿主自行管理的请求头
+var forbiddenHeaders = map[string]bool{
+	"Host":              true,
+	"Connection":        true,
+	"Keep-Alive":        true,
+	"Proxy-Connection":  true,
+	"Transfer-Encoding": true,
+	"Upgrade":           true,
+	"Te":                true,
+	"Trailer":           true,
+	"Http2-Settings":    true,
+}
+
+// RoundTrip 出错时关闭 req.Body；body 已交给发送的 goroutine 时由该 goroutine 在写完或失败后关闭
+func (t *Transport) RoundTrip(req *http.Request) (*http.Response, error) {
+	resp, sending, err := t.roundTrip(req)
+	if err != nil && !sending && req.Body != nil {
+		req.Body.Close()
+	}
+	return resp, err
+}
+
+// roundTrip 发送请求，sending 表示 req.Body 是否已交给发送的 goroutine
+func (t *Transport) roundTrip(req *http.Request) (resp *http.Response, sending bool, err error) {
+	if req.URL == nil {
+		return nil, false, errors.New("http: nil Request.URL")
+	}
+	if req.URL.Host == "" {
+		return nil, false, errors.New("http: no Host in request URL")
+	}
+	ctx, cancel := context.WithCancelCause(req.Context())
+
+	request, err := t.newOutgoingRequest(req)
+	if err != nil {
+		cancel(err)
+		return nil, false, err
+	}
+	// 请求交给宿主前取得 body，交出后 request 的所有权转移给宿主
+	body, _, _ := request.Body().Result()
+
+	var options cm.Option[httptypes.RequestOptions]
+	if t.ConnectTimeout > 0 || t.FirstByteTimeout > 0 || t.BetweenBytesTimeout > 0 {
+		options = cm.Some(t.newRequestOptions())
+	}
+	future, code, isErr := outgoinghandler.Handle(request, options).Result()
+	if isErr {
+		body.ResourceDrop()
+		err := &HTTPError{Code: code}
+		cancel(err)
+		return nil, false, err
+	}
+
+	// 与等待响应并行发送 body，服务端可能在读完 body 前就返回响应
+	// 发送失败时通过 cancel 中断响应的等待与读取
+	if req.Body == nil || req.Body == http.NoBody {
+		if err := finishOutgoingBody(body, req.Trailer); err != nil {
+			future.ResourceDrop()
+			cancel(err)
+			return nil, false, err
+		}
+	} else {
+		sending = true
+		go func() {
+			if err := writeOutgoingBody(ctx, body, req.Body, req.Trailer); err != nil {
+				cancel(err)
+			}
+		}()
+	}
+
+	incoming, err := awaitResponse(ctx, future)
+	if err != nil {
+		future.ResourceDrop()
+		cancel(err)
+		return nil, sending, err
+	}
+
+	status := int(incoming.Status())
+	resp = &http.Response{
+		Status:        strconv.Itoa(status) + " " + http.StatusText(status),
+		StatusCode:    status,
+		Proto:         "HTTP/1.1",
+		ProtoMajor:    1,
+		ProtoMinor:    1,
+		Header:        responseHeaders(incoming),
+		ContentLength: -1,
+		Request:       req,
+	}
+	if cl := resp.Header.Get("Content-Length"); cl != "" {
+		if n, err := strconv.ParseInt(cl, 10, 64); err == nil && n >= 0 {
+			resp.ContentLength = n
+		}
+	}
+
+	rb := &responseBody{future: future, response: incoming, cancel: cancel}
+	if ib, _, isErr := incoming.Consume().Result(); !isErr {
+		rb.body = ib
+		if stream, _, isErr := ib.Stream().Result(); !isErr {
+			rb.stream = NewInputStreamContext(ctx, stream)
+			rb.hasStream = true
+		}
+	}
+	resp.Body = rb
+	return resp, sending, nil
+}
+
+func (t *Transport) newOutgoingRequest(req *http.Request) (httptypes.OutgoingRequest, error) {
+	headers, err := NewHeaders(outgoingHeader(req))
+	if err != nil {
+		return 0, err
+	}
+	// headers 的所有权转移给 request
+	request := httptypes.NewOutgoingRequest(headers)
+
+	authority := req.Host
+	if authority == "" {
+		authority = req.URL.Host
+	}
+	scheme := toScheme(req.URL.Scheme)
+
+	if request.SetMethod(toMethod(req.Method)) != cm.ResultOK {
+		request.ResourceDrop()
+		return 0, fmt.Errorf("http: invalid method %q", req.Method)
+	}
+	if request.SetScheme(cm.Some(scheme)) != cm.ResultOK {
+		request.ResourceDrop()
+		return 0, fmt.Errorf("http: invalid scheme %q", req.URL.Scheme)
+	}
+	if request.SetAuthority(cm.Some(authority)) != cm.ResultOK {
+		request.ResourceDrop()
+		return 0, fmt.Errorf("http: invalid host %q", authority)
+	}
+	if request.SetPathWithQuery(cm.Some(req.URL.RequestURI())) != cm.ResultOK {
+		request.ResourceDrop()
+		return 0, fmt.Errorf("http: invalid path %q", req.URL.RequestURI())
+	}
+	return request, nil
+}
+
+// outgoingHeader 返回发送给宿主的请求头，去掉宿主禁止的头并补充 Content-Length
+func outgoingHeader(req *http.Request) http.Header {
+	header := make(http.Header, len(req.Header)+1)
+	for name, values := range req.Header {
+		if !forbiddenHeaders[http.CanonicalHeaderKey(name)] {
+			header[name] = values
+		}
+	}
+	if req.ContentLength > 0 && header.Get("Content-Length") == "" {
+		header.Set("Content-Length", strconv.FormatInt(req.ContentLength, 10))
+	}
+	return header
+}
+
+func toScheme(scheme string) httptypes.Scheme {
+	switch strings.ToLower(scheme) {
+	case "http":
+		return httptypes.SchemeHTTP()
+	case "https":
+		return httptypes.SchemeHTTPS()
+	default:
+		return httptypes.SchemeOther(scheme)
+	}
+}
+
+func (t *Transport) newRequestOptions() httptypes.RequestOptions {
+	options := httptypes.NewRequestOptions()
+	if t.ConnectTimeout > 0 {
+		options.SetConnectTimeout(cm.Some(httptypes.Duration(t.ConnectTimeout)))
+	}
+	if t.FirstByteTimeout > 0 {
+		options.SetFirstByteTimeout(cm.Some(httptypes.Duration(t.FirstByteTimeout)))
+	}
+	if t.BetweenBytesTimeout > 0 {
+		options.SetBetweenBytesTimeout(cm.Some(httptypes.Duration(t.BetweenBytesTimeout)))
+	}
+	return options
+}
+
+func toMethod(method string) httptypes.Method {
+	switch method {
+	case "", http.MethodGet:
+		return httptypes.MethodGet()
+	case http.MethodHead:
+		return httptypes.MethodHead()
+	case http.MethodPost:
+		return httptypes.MethodPost()
+	case http.MethodPut:
+		return httptypes.MethodPut()
+	case http.MethodDelete:
+		return httptypes.MethodDelete()
+	case http.MethodConnect:
+		return httptypes.MethodConnect()
+	case http.MethodOptions:
+		return httptypes.MethodOptions()
+	case http.MethodTrace:
+		return httptypes.MethodTrace()
+	case http.MethodPatch:
+		return httptypes.MethodPatch()
+	default:
+		return httptypes.MethodOther(method)
+	}
+}
+
+// writeOutgoingBody 将 r 写入请求 body 并结束 body
+// 出错时直接丢弃 body，宿主会将请求视为不完整
+func writeOutgoingBody(ctx context.Context, body httptypes.OutgoingBody, r io.ReadCloser, trailer http.Header) error {
+	defer r.Close()
+
+	stream, _, isErr := body.Write().Result()
+	if isErr {
+		body.ResourceDrop()
+		return errors.New("http: request body already taken")
+	}
+	out := NewOutputStreamContext(ctx, stream)
+	_, err := io.Copy(&out, r)
+	// 输出流必须在 finish 之前释放
+	if cerr := out.Close(); err == nil {
+		err = cerr
+	}
+	if err != nil {
+		body.ResourceDrop()
+		return err
+	}
+	return finishOutgoingBody(body, trailer)
+}
+
+func finishOutgoingBody(body httptypes.OutgoingBody, trailer http.Header) error {
+	var trailers cm.Option[httptypes.Trailers]
+	if len(trailer) > 0 {
+		fields, err := NewHeaders(trailer)
+		if err != nil {
+			body.ResourceDrop()
+			return err
+		}
+		trailers = cm.Some(fields)
+	}
+	if _, code, isErr := httptypes.OutgoingBodyFinish(body, trailers).Result(); isErr {
+		return &HTTPError{Code: code}
+	}
+	return nil
+}
+
+// awaitResponse 等待响应头到达，ctx 取消时返回取消原因
+func awaitResponse(ctx context.Context, future httptypes.FutureIncomingResponse) (httptypes.IncomingResponse, error) {
+	pollable := future.Subscribe()
+	err := waitPollable(ctx, pollable, time.Time{})
+	pollable.ResourceDrop()
+	if err != nil {
+		if cause := context.Cause(ctx); cause != nil && errors.Is(err, context.Canceled) {
+			return 0, cause
+		}
+		return 0, err
+	}
+
+	got := future.Get()
+	ready := got.Some()
+	if ready == nil {
+		return 0, errors.New("http: response not ready")
+	}
+	result, _, isErr := ready.Result()
+	if isErr {
+		return 0, errors.New("http: response already taken")
+	}
+	incoming, code, isErr := result.Result()
+	if isErr {
+		return 0, &HTTPError{Code: code}
+	}
+	return incoming, nil
+}
+
+func responseHeaders(incoming httptypes.IncomingResponse) http.Header {
+	fields := incoming.Headers()
+	defer fields.ResourceDrop()
+
+	entries := fields.Entries().Slice()
+	header := make(http.Header, len(entries))
+	for _, entry := range entries {
+		// 规范化后的 key 可能与原字符串共享内存，先拷贝再释放
+		name := string(entry.F0)
+		value := entry.F1.Slice()
+		header.Add(strings.Clone(name), string(value))
+		freeWasiString(name)
+		freeWasiSlice(value)
+	}
+	freeWasiSlice(entries)
+	return header
+}
+
+// responseBody 响应 body，Close 时按子资源到父资源的顺序释放
+type responseBody struct {
+	stream    InputStream
+	hasStream bool
+	body      httptypes.IncomingBody
+	response  httptypes.IncomingResponse
+	future    httptypes.FutureIncomingResponse
+	cancel    context.CancelCauseFunc
+	closed    bool
+}
+
+func (b *responseBody) Read(p []byte) (int, error) {
+	if b.closed {
+		return 0, errors.New("http: read on closed response body")
+	}
+	if !b.hasStream {
+		return 0, io.EOF
+	}
+	return b.stream.Read(p)
+}
+
+// WriteTo 使 io.Copy 能够直接在宿主流之间 splice
+func (b *responseBody) WriteTo(w io.Writer) (int64, error) {
+	if b.closed {
+		return 0, errors.New("http: read on closed response body")
+	}
+	if !b.hasStream {
+		return 0, nil
+	}
+	return b.stream.WriteTo(w)
+}
+
+func (b *responseBody) Close() error {
+	if b.closed {
+		return nil
+	}
+	b.closed = true
+	if b.hasStream {
+		b.stream.Close()
+	}
+	if b.body != 0 {
+		httptypes.IncomingBodyFinish(b.body).ResourceDrop()
+	}
+	b.response.ResourceDrop()
+	b.future.ResourceDrop()
+	b.cancel(nil)
+	return nil
+}
@@ -0,0 +1,169 @@
+package adapter
+
+import (
+	"errors"
+	"net/http"
+	"net/url"
+	"testing"
+
+	httptypes "github.com/OpenListTeam/openlist-wasi-plugin-driver/binding/wasi/http/types"
+	"go.bytecodealliance.org/cm"
+)
+
+func TestToMethod(t *testing.T) {
+	tests := []struct {
+		method string
+		want   string
+	}{
+		{"", "get"},
+		{http.MethodGet, "get"},
+		{http.MethodHead, "head"},
+		{http.MethodPost, "post"},
+		{http.MethodPut, "put"},
+		{http.MethodDelete, "delete"},
+		{http.MethodConnect, "connect"},
+		{http.MethodOptions, "options"},
+		{http.MethodTrace, "trace"},
+		{http.MethodPatch, "patch"},
+		{"PROPFIND", "other"},
+		// 方法名区分大小写
+		{"get", "other"},
+	}
+	for _, tt := range tests {
+		m := toMethod(tt.method)
+		if got := m.String(); got != tt.want {
+			t.Errorf("toMethod(%q) = %s, want %s", tt.method, got, tt.want)
+			continue
+		}
+		if other := m.Other(); tt.want == "other" && (other == nil || *other != tt.method) {
+			t.Errorf("toMethod(%q) = other(%v)", tt.method, other)
+		}
+	}
+}
+
+func TestToScheme(t *testing.T) {
+	for _, tt := range []struct{ scheme, want string }{
+		{"http", "HTTP"},
+		{"HTTPS", "HTTPS"},
+		{"ftp", "other"},
+	} {
+		s := toScheme(tt.scheme)
+		if got := s.String(); got != tt.want {
+			t.Errorf("toScheme(%q) = %s, want %s", tt.scheme, got, tt.want)
+		}
+	}
+}
+
+func TestOutgoingHeader(t *testing.T) {
+	req := &http.Request{
+		Header: http.Header{
+			"Authorization":     {"Bearer x"},
+			"X-Multi":           {"a", "b"},
+			"Connection":        {"close"},
+			"Transfer-Encoding": {"chunked"},
+			"Host":              {"example.com"},
+			// 非规范形式的禁止头同样被去掉
+			"keep-alive": {"timeout=5"},
+		},
+		ContentLength: 42,
+	}
+	header := outgoingHeader(req)
+	for _, name := range []string{"Connection", "Transfer-Encoding", "Host", "keep-alive"} {
+		if _, ok := header[name]; ok {
+			t.Errorf("forbidden header %s was kept", name)
+		}
+	}
+	if got := header.Get("Authorization"); got != "Bearer x" {
+		t.Errorf("Authorization = %q", got)
+	}
+	if got := header.Values("X-Multi"); len(got) != 2 {
+		t.Errorf("X-Multi = %v", got)
+	}
+	if got := header.Get("Content-Length"); got != "42" {
+		t.Errorf("Content-Length = %q, want 42", got)
+	}
+	if _, ok := req.Header["Content-Length"]; ok {
+		t.Error("outgoingHeader modified req.Header")
+	}
+
+	// 已有的 Content-Length 不被覆盖，长度未知时不补充
+	req = &http.Request{Header: http.Header{"Content-Length": {"7"}}, ContentLength: 42}
+	if got := outgoingHeader(req).Get("Content-Length"); got != "7" {
+		t.Errorf("Content-Length = %q, want 7", got)
+	}
+	req = &http.Request{Header: http.Header{}, ContentLength: -1}
+	if _, ok := outgoingHeader(req)["Content-Length"]; ok {
+		t.Error("Content-Length added for unknown length")
+	}
+}
+
+func TestRedirectPolicy(t *testing.T) {
+	via := func(n int) []*http.Request { return make([]*http.Request, n) }
+	tests := []struct {
+		max  int
+		via  int
+		want bool
+	}{
+		{0, DefaultMaxRedirects - 1, true},
+		{0, DefaultMaxRedirects, false},
+		{2, 1, true},
+		{2, 2, false},
+	}
+	for _, tt := range tests {
+		err := redirectPolicy(tt.max)(nil, via(tt.via))
+		if (err == nil) != tt.want {
+			t.Errorf("redirectPolicy(%d) after %d redirects = %v", tt.max, tt.via, err)
+		}
+	}
+	if err := redirectPolicy(-1)(nil, via(1)); !errors.Is(err, http.ErrUseLastResponse) {
+		t.Errorf("redirectPolicy(-1) = %v, want http.ErrUseLastResponse", err)
+	}
+}
+
+func TestNewHTTPClient(t *testing.T) {
+	transport := &Transport{}
+	c := NewHTTPClient(HTTPClientOptions{Transport: transport, MaxRedirects: -1})
+	if c.Transport != transport {
+		t.Error("Transport not used")
+	}
+	if err := c.CheckRedirect(nil, nil); !errors.Is(err, http.ErrUseLastResponse) {
+		t.Errorf("CheckRedirect = %v, want http.ErrUseLastResponse", err)
+	}
+
+	// 默认创建 cookiejar，同一客户端的请求共享 cookie
+	c = NewHTTPClient(HTTPClientOptions{})
+	if c.Transport != DefaultTransport || c.Jar == nil {
+		t.Fatalf("client = %+v", c)
+	}
+	u, _ := url.Parse("https://example.com/")
+	c.Jar.SetCookies(u, []*http.Cookie{{Name: "session", Value: "1"}})
+	if got := c.Jar.Cookies(u); len(got) != 1 || got[0].Value != "1" {
+		t.Errorf("cookies = %v", got)
+	}
+}
+
+func TestHTTPError(t *testing.T) {
+	tests := []struct {
+		code    httptypes.ErrorCode
+		timeout bool
+		msg     string
+	}{
+		{httptypes.ErrorCodeDNSTimeout(), true, "wasi http: DNS-timeout"},
+		{httptypes.ErrorCodeConnectionTimeout(), true, "wasi http: connection-timeout"},
+		{httptypes.ErrorCodeConnectionReadTimeout(), true, "wasi http: connection-read-timeout"},
+		{httptypes.ErrorCodeConnectionWriteTimeout(), true, "wasi http: connection-write-timeout"},
+		{httptypes.ErrorCodeHTTPResponseTimeout(), true, "wasi http: HTTP-response-timeout"},
+		{httptypes.ErrorCodeConnectionRefused(), false, "wasi http: connection-refused"},
+		{httptypes.ErrorCodeDNSError(httptypes.DNSErrorPayload{Rcode: cm.Some("NXDOMAIN")}), false, "wasi http: DNS error: NXDOMAIN"},
+		{httptypes.ErrorCodeInternalError(cm.Some("boom")), false, "wasi http: internal error: boom"},
+	}
+	for _, tt := range tests {
+		err := &HTTPError{Code: tt.code}
+		if err.Timeout() != tt.timeout || err.Temporary() != tt.timeout {
+			t.Errorf("%v: Timeout() = %v, want %v", err, err.Timeout(), tt.timeout)
+		}
+		if err.Error() != tt.msg {
+			t.Errorf("Error() = %q, want %q", err.Error(), tt.msg)
+		}
+	}
+}
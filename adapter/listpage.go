@@ -0,0 +1,134 @@
+package adapter
+
+import (
+	"errors"
+	"strconv"
+	"strings"
+	"sync"
+
+	drivertypes "github.com/OpenListTeam/openlist-wasi-plugin-driver/binding/openlist/plugin-driver/types"
+)
+
+// maxListSessions ListPageCache 最多保留的未读完的列表数量，超出时丢弃最早的
+const maxListSessions = 8
+
+// ListPageCache 为未实现 ListPager 的驱动在插件内分页
+// 第一页时调用一次 ListFiles 并缓存完整列表，后续页直接从缓存中读取，最后一页返回后释放
+// 游标为 "会话:偏移量"，会话已被丢弃时重新列出目录并按偏移量继续
+type ListPageCache struct {
+	mu       sync.Mutex
+	nextID   uint64
+	sessions map[uint64]listSession
+	// 按创建顺序排列的会话，用于丢弃最早的会话
+	order []uint64
+}
+
+type listSession struct {
+	dir  string
+	objs []drivertypes.Object
+}
+
+// Page 返回 dir 从 cursor 开始的最多 limit 个对象，limit 为 0 时返回全部
+// list 用于列出完整目录，只在第一页或会话已被丢弃时调用
+func (c *ListPageCache) Page(dir string, cursor string, limit uint32, list func() ([]drivertypes.Object, error)) ([]drivertypes.Object, string, error) {
+	var (
+		id     uint64
+		offset int
+	)
+	if cursor != "" {
+		var err error
+		if id, offset, err = parseListCursor(cursor); err != nil {
+			return nil, "", err
+		}
+	}
+
+	objs, ok := c.get(id, dir)
+	if !ok {
+		var err error
+		if objs, err = list(); err != nil {
+			return nil, "", err
+		}
+	}
+
+	offset = min(offset, len(objs))
+	end := len(objs)
+	if limit > 0 {
+		end = min(offset+int(limit), len(objs))
+	}
+	if end == len(objs) {
+		if ok {
+			c.drop(id)
+		}
+		return objs[offset:end], "", nil
+	}
+	if !ok {
+		id = c.put(dir, objs)
+	}
+	return objs[offset:end], strconv.FormatUint(id, 10) + ":" + strconv.Itoa(end), nil
+}
+
+// get 返回会话缓存的列表，会话不存在或目录不同时返回 false
+func (c *ListPageCache) get(id uint64, dir string) ([]drivertypes.Object, bool) {
+	if id == 0 {
+		return nil, false
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	s, ok := c.sessions[id]
+	if !ok || s.dir != dir {
+		return nil, false
+	}
+	return s.objs, true
+}
+
+func (c *ListPageCache) put(dir string, objs []drivertypes.Object) uint64 {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.nextID++
+	c.add(c.nextID, listSession{dir: dir, objs: objs})
+	return c.nextID
+}
+
+func (c *ListPageCache) drop(id uint64) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.remove(id)
+}
+
+func (c *ListPageCache) add(id uint64, s listSession) {
+	if c.sessions == nil {
+		c.sessions = make(map[uint64]listSession)
+	}
+	for len(c.order) >= maxListSessions {
+		delete(c.sessions, c.order[0])
+		c.order = c.order[1:]
+	}
+	c.sessions[id] = s
+	c.order = append(c.order, id)
+}
+
+func (c *ListPageCache) remove(id uint64) {
+	delete(c.sessions, id)
+	for i, v := range c.order {
+		if v == id {
+			c.order = append(c.order[:i], c.order[i+1:]...)
+			break
+		}
+	}
+}
+
+func parseListCursor(cursor string) (uint64, int, error) {
+	idStr, offsetStr, ok := strings.Cut(cursor, ":")
+	if !ok {
+		return 0, 0, errors.New("invalid cursor: " + cursor)
+	}
+	id, err := strconv.ParseUint(idStr, 10, 64)
+	if err != nil || id == 0 {
+		return 0, 0, errors.New("invalid cursor: " + cursor)
+	}
+	offset, err := strconv.Atoi(offsetStr)
+	if err != nil || offset < 0 {
+		return 0, 0, errors.New("invalid cursor: " + cursor)
+	}
+	return id, offset, nil
+}
@@ -0,0 +1,136 @@
+package adapter
+
+import (
+	"strconv"
+	"testing"
+
+	drivertypes "github.com/OpenListTeam/openlist-wasi-plugin-driver/binding/openlist/plugin-driver/types"
+)
+
+func testObjects(n int) []drivertypes.Object {
+	objs := make([]drivertypes.Object, n)
+	for i := range objs {
+		objs[i].Name = strconv.Itoa(i)
+	}
+	return objs
+}
+
+// readAll 从第一页开始读到最后一页，返回所有对象的名称
+func readAll(t *testing.T, c *ListPageCache, dir string, limit uint32, list func() ([]drivertypes.Object, error)) []string {
+	t.Helper()
+	var names []string
+	cursor := ""
+	for {
+		objs, next, err := c.Page(dir, cursor, limit, list)
+		if err != nil {
+			t.Fatalf("Page(%q): %v", cursor, err)
+		}
+		if limit > 0 && len(objs) > int(limit) {
+			t.Fatalf("Page(%q) returned %d objects, limit %d", cursor, len(objs), limit)
+		}
+		for _, obj := range objs {
+			names = append(names, obj.Name)
+		}
+		if next == "" {
+			return names
+		}
+		cursor = next
+	}
+}
+
+func TestListPageCache(t *testing.T) {
+	var c ListPageCache
+	calls := 0
+	list := func() ([]drivertypes.Object, error) {
+		calls++
+		return testObjects(10), nil
+	}
+
+	names := readAll(t, &c, "/a", 3, list)
+	if len(names) != 10 {
+		t.Fatalf("got %d objects, want 10", len(names))
+	}
+	for i, name := range names {
+		if name != strconv.Itoa(i) {
+			t.Fatalf("object %d = %q", i, name)
+		}
+	}
+	if calls != 1 {
+		t.Errorf("ListFiles called %d times, want 1", calls)
+	}
+	if len(c.sessions) != 0 || len(c.order) != 0 {
+		t.Errorf("session not released after the last page: %v", c.order)
+	}
+}
+
+func TestListPageCacheSinglePage(t *testing.T) {
+	var c ListPageCache
+	list := func() ([]drivertypes.Object, error) { return testObjects(5), nil }
+	for _, limit := range []uint32{0, 5, 100} {
+		objs, next, err := c.Page("/a", "", limit, list)
+		if err != nil || len(objs) != 5 || next != "" {
+			t.Errorf("Page(limit %d) = %d objects, %q, %v", limit, len(objs), next, err)
+		}
+	}
+	if len(c.sessions) != 0 {
+		t.Errorf("single page listing kept %d sessions", len(c.sessions))
+	}
+}
+
+func TestListPageCacheEvicted(t *testing.T) {
+	var c ListPageCache
+	calls := 0
+	list := func() ([]drivertypes.Object, error) {
+		calls++
+		return testObjects(10), nil
+	}
+
+	_, first, err := c.Page("/a", "", 4, list)
+	if err != nil {
+		t.Fatal(err)
+	}
+	// 打开更多的列表使第一个会话被丢弃
+	for i := 0; i < maxListSessions; i++ {
+		if _, _, err := c.Page("/b", "", 4, list); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	calls = 0
+	objs, next, err := c.Page("/a", first, 4, list)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if calls != 1 {
+		t.Errorf("evicted session: ListFiles called %d times, want 1", calls)
+	}
+	if len(objs) != 4 || objs[0].Name != "4" || next == "" {
+		t.Errorf("evicted session: page = %d objects from %q, next %q", len(objs), objs[0].Name, next)
+	}
+}
+
+func TestListPageCacheOtherDir(t *testing.T) {
+	var c ListPageCache
+	_, next, err := c.Page("/a", "", 2, func() ([]drivertypes.Object, error) { return testObjects(4), nil })
+	if err != nil {
+		t.Fatal(err)
+	}
+	// 游标用于其他目录时不使用缓存
+	objs, _, err := c.Page("/b", next, 2, func() ([]drivertypes.Object, error) { return testObjects(3), nil })
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(objs) != 1 {
+		t.Errorf("got %d objects from /b, want 1", len(objs))
+	}
+}
+
+func TestListPageCacheInvalidCursor(t *testing.T) {
+	var c ListPageCache
+	list := func() ([]drivertypes.Object, error) { return testObjects(1), nil }
+	for _, cursor := range []string{"abc", "1", "0:1", "1:-1", "x:1", "1:x"} {
+		if _, _, err := c.Page("/a", cursor, 1, list); err == nil {
+			t.Errorf("Page(%q) succeeded", cursor)
+		}
+	}
+}
@@ -0,0 +1,769 @@
+package adapter
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/OpenListTeam/openlist-wasi-plugin-driver/binding/wasi/io/poll"
+	"github.com/OpenListTeam/openlist-wasi-plugin-driver/binding/wasi/sockets/network"
+	"github.com/OpenListTeam/openlist-wasi-plugin-driver/binding/wasi/sockets/tcp"
+	tcpcreatesocket "github.com/OpenListTeam/openlist-wasi-plugin-driver/binding/wasi/sockets/tcp-create-socket"
+	"github.com/OpenListTeam/openlist-wasi-plugin-driver/binding/wasi/sockets/udp"
+	udpcreatesocket "github.com/OpenListTeam/openlist-wasi-plugin-driver/binding/wasi/sockets/udp-create-socket"
+	"go.bytecodealliance.org/cm"
+)
+
+// DefaultKeepAlive Dialer 未设置 KeepAlive 时的 TCP keep-alive 间隔，与 net 包一致
+const DefaultKeepAlive = 15 * time.Second
+
+// 关闭连接时等待缓冲数据写出的最长时间
+const closeFlushTimeout = 5 * time.Second
+
+// Dialer 通过 wasi:sockets 建立 TCP/UDP 连接，用法与 net.Dialer 相同
+// 在驱动方法内应使用 WarpCancellable 得到的 ctx 调用 DialContext
+type Dialer struct {
+	// 建立连接（含域名解析）的超时，零值表示不限制
+	Timeout time.Duration
+	// 建立连接的截止时间，与 Timeout 同时设置时取较早者
+	Deadline time.Time
+	// 本地地址，需与 network 匹配（*net.TCPAddr 或 *net.UDPAddr），为 nil 时由宿主分配
+	LocalAddr net.Addr
+	// TCP keep-alive 间隔，零值使用 DefaultKeepAlive，负数表示关闭
+	KeepAlive time.Duration
+}
+
+// Dial 使用零值 Dialer 建立连接
+func Dial(network, address string) (net.Conn, error) {
+	var d Dialer
+	return d.Dial(network, address)
+}
+
+func (d *Dialer) Dial(network, address string) (net.Conn, error) {
+	return d.DialContext(context.Background(), network, address)
+}
+
+// DialContext 建立连接，network 支持 tcp、tcp4、tcp6、udp、udp4、udp6
+// 域名解析出多个地址时依次尝试，返回第一个成功的连接
+func (d *Dialer) DialContext(ctx context.Context, network, address string) (net.Conn, error) {
+	proto, family, err := parseNetwork(network)
+	if err != nil {
+		return nil, &net.OpError{Op: "dial", Net: network, Err: err}
+	}
+	if d.Timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, d.Timeout)
+		defer cancel()
+	}
+	if !d.Deadline.IsZero() {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithDeadline(ctx, d.Deadline)
+		defer cancel()
+	}
+
+	host, port, err := splitHostPort(address)
+	if err != nil {
+		return nil, &net.OpError{Op: "dial", Net: network, Err: err}
+	}
+	ips, err := resolveAddrs(ctx, host, family, true)
+	if err != nil {
+		return nil, &net.OpError{Op: "dial", Net: network, Err: err}
+	}
+
+	var firstErr error
+	for _, ip := range ips {
+		var conn net.Conn
+		if proto == "tcp" {
+			conn, err = d.dialTCP(ctx, ip, port)
+		} else {
+			conn, err = d.dialUDP(ip, port)
+		}
+		if err == nil {
+			return conn, nil
+		}
+		if firstErr == nil {
+			firstErr = &net.OpError{Op: "dial", Net: network, Addr: addrOf(proto, ip, port), Err: err}
+		}
+		if ctx.Err() != nil {
+			break
+		}
+	}
+	return nil, firstErr
+}
+
+func (d *Dialer) dialTCP(ctx context.Context, ip net.IP, port int) (net.Conn, error) {
+	remote, family := toSocketAddress(ip, port)
+	socket, code, isErr := tcpcreatesocket.CreateTCPSocket(family).Result()
+	if isErr {
+		return nil, &SocketError{Code: code}
+	}
+
+	if err := d.bindTCP(ctx, socket, family); err != nil {
+		socket.ResourceDrop()
+		return nil, err
+	}
+	if d.KeepAlive >= 0 {
+		keepAlive := d.KeepAlive
+		if keepAlive == 0 {
+			keepAlive = DefaultKeepAlive
+		}
+		// 与 net 包一样尽力设置，宿主不支持时忽略
+		socket.SetKeepAliveEnabled(true)
+		socket.SetKeepAliveIdleTime(tcp.Duration(keepAlive))
+		socket.SetKeepAliveInterval(tcp.Duration(keepAlive))
+	}
+
+	if _, code, isErr := socket.StartConnect(defaultNetwork(), remote).Result(); isErr {
+		socket.ResourceDrop()
+		return nil, &SocketError{Code: code}
+	}
+	streams, err := awaitSocket(ctx, nil, socket.Subscribe, func() (cm.Tuple[tcp.InputStream, tcp.OutputStream], network.ErrorCode, bool) {
+		return socket.FinishConnect().Result()
+	})
+	if err != nil {
+		socket.ResourceDrop()
+		return nil, err
+	}
+	return newTCPConn(socket, streams.F0, streams.F1), nil
+}
+
+// bindTCP 按 LocalAddr 绑定本地地址，IP 为空时使用 family 的全零地址
+func (d *Dialer) bindTCP(ctx context.Context, socket tcp.TCPSocket, family network.IPAddressFamily) error {
+	if d.LocalAddr == nil {
+		return nil
+	}
+	laddr, ok := d.LocalAddr.(*net.TCPAddr)
+	if !ok {
+		return &net.AddrError{Err: "mismatched local address type", Addr: d.LocalAddr.String()}
+	}
+	ip := laddr.IP
+	if ip == nil {
+		ip = unspecifiedIP(family)
+	}
+	local, _ := toSocketAddress(ip, laddr.Port)
+	if _, code, isErr := socket.StartBind(defaultNetwork(), local).Result(); isErr {
+		return &SocketError{Code: code}
+	}
+	_, err := awaitSocket(ctx, nil, socket.Subscribe, func() (struct{}, network.ErrorCode, bool) {
+		return socket.FinishBind().Result()
+	})
+	return err
+}
+
+func (d *Dialer) dialUDP(ip net.IP, port int) (net.Conn, error) {
+	var localIP net.IP
+	var localPort int
+	if d.LocalAddr != nil {
+		laddr, ok := d.LocalAddr.(*net.UDPAddr)
+		if !ok {
+			return nil, &net.AddrError{Err: "mismatched local address type", Addr: d.LocalAddr.String()}
+		}
+		localIP, localPort = laddr.IP, laddr.Port
+	}
+	remote, family := toSocketAddress(ip, port)
+	socket, err := bindUDP(family, localIP, localPort)
+	if err != nil {
+		return nil, err
+	}
+
+	streams, code, isErr := socket.Stream(cm.Some(remote)).Result()
+	if isErr {
+		socket.ResourceDrop()
+		return nil, &SocketError{Code: code}
+	}
+	return newUDPConn(socket, streams.F0, streams.F1), nil
+}
+
+// bindUDP 创建并绑定 UDP 套接字，wasi 要求 UDP 套接字在收发前显式绑定
+func bindUDP(family network.IPAddressFamily, ip net.IP, port int) (udp.UDPSocket, error) {
+	socket, code, isErr := udpcreatesocket.CreateUDPSocket(family).Result()
+	if isErr {
+		return 0, &SocketError{Code: code}
+	}
+	if ip == nil {
+		ip = unspecifiedIP(family)
+	}
+	local, _ := toSocketAddress(ip, port)
+	if _, code, isErr := socket.StartBind(defaultNetwork(), local).Result(); isErr {
+		socket.ResourceDrop()
+		return 0, &SocketError{Code: code}
+	}
+	// UDP 绑定不涉及网络往返，不响应取消
+	_, err := awaitSocket(context.Background(), nil, socket.Subscribe, func() (struct{}, network.ErrorCode, bool) {
+		return socket.FinishBind().Result()
+	})
+	if err != nil {
+		socket.ResourceDrop()
+		return 0, err
+	}
+	return socket, nil
+}
+
+// parseNetwork 解析 network 参数，family 为 nil 表示不限制地址族
+func parseNetwork(name string) (string, *network.IPAddressFamily, error) {
+	var proto string
+	switch {
+	case strings.HasPrefix(name, "tcp"):
+		proto = "tcp"
+	case strings.HasPrefix(name, "udp"):
+		proto = "udp"
+	default:
+		return "", nil, net.UnknownNetworkError(name)
+	}
+	switch name[len(proto):] {
+	case "":
+		return proto, nil, nil
+	case "4":
+		family := network.IPAddressFamilyIPv4
+		return proto, &family, nil
+	case "6":
+		family := network.IPAddressFamilyIPv6
+		return proto, &family, nil
+	default:
+		return "", nil, net.UnknownNetworkError(name)
+	}
+}
+
+// resolveAddrs 解析 host 并按地址族过滤，host 为空时拨号使用回环地址、监听使用全零地址
+func resolveAddrs(ctx context.Context, host string, family *network.IPAddressFamily, dial bool) ([]net.IP, error) {
+	if host == "" {
+		return []net.IP{emptyHostIP(family, dial)}, nil
+	}
+	ips, err := LookupIP(ctx, host)
+	if err != nil {
+		return nil, err
+	}
+	return filterFamily(host, ips, family)
+}
+
+// emptyHostIP 返回 host 为空时使用的地址，未指定地址族时使用 IPv4
+func emptyHostIP(family *network.IPAddressFamily, dial bool) net.IP {
+	f := network.IPAddressFamilyIPv4
+	if family != nil {
+		f = *family
+	}
+	if !dial {
+		return unspecifiedIP(f)
+	}
+	if f == network.IPAddressFamilyIPv6 {
+		return net.IPv6loopback
+	}
+	return net.IPv4(127, 0, 0, 1)
+}
+
+// filterFamily 保留属于 family 的地址，IPv4 映射的 IPv6 地址视为 IPv4，family 为 nil 时不过滤
+func filterFamily(host string, ips []net.IP, family *network.IPAddressFamily) ([]net.IP, error) {
+	if family == nil {
+		return ips, nil
+	}
+	filtered := ips[:0]
+	for _, ip := range ips {
+		if (ip.To4() != nil) == (*family == network.IPAddressFamilyIPv4) {
+			filtered = append(filtered, ip)
+		}
+	}
+	if len(filtered) == 0 {
+		return nil, &net.AddrError{Err: "no suitable address found", Addr: host}
+	}
+	return filtered, nil
+}
+
+func unspecifiedIP(family network.IPAddressFamily) net.IP {
+	if family == network.IPAddressFamilyIPv6 {
+		return net.IPv6unspecified
+	}
+	return net.IPv4zero
+}
+
+func addrOf(proto string, ip net.IP, port int) net.Addr {
+	if proto == "udp" {
+		return &net.UDPAddr{IP: ip, Port: port}
+	}
+	return &net.TCPAddr{IP: ip, Port: port}
+}
+
+// connState 连接的关闭状态，Close 取消 ctx 使阻塞中的读写返回，再等待它们退出后释放资源
+type connState struct {
+	ctx    context.Context
+	cancel context.CancelFunc
+	// 读写持有读锁，Close 持有写锁
+	mu     sync.RWMutex
+	closed bool
+}
+
+func newConnState() connState {
+	ctx, cancel := context.WithCancel(context.Background())
+	return connState{ctx: ctx, cancel: cancel}
+}
+
+// enter 开始一次读写，连接已关闭时返回 false
+func (s *connState) enter() bool {
+	s.mu.RLock()
+	if s.closed {
+		s.mu.RUnlock()
+		return false
+	}
+	return true
+}
+
+func (s *connState) leave() {
+	s.mu.RUnlock()
+}
+
+// close 取消阻塞中的读写并等待其退出，重复关闭时返回 false
+func (s *connState) close() bool {
+	s.cancel()
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.closed {
+		return false
+	}
+	s.closed = true
+	return true
+}
+
+// err 将连接关闭导致的 context.Canceled 转换为 net.ErrClosed
+func (s *connState) err(err error) error {
+	if errors.Is(err, context.Canceled) && s.ctx.Err() != nil {
+		return net.ErrClosed
+	}
+	return err
+}
+
+// TCPConn 基于 wasi:sockets/tcp 的 net.Conn
+type TCPConn struct {
+	state  connState
+	socket tcp.TCPSocket
+	in     InputStream
+	out    OutputStream
+	laddr  *net.TCPAddr
+	raddr  *net.TCPAddr
+}
+
+func newTCPConn(socket tcp.TCPSocket, in tcp.InputStream, out tcp.OutputStream) *TCPConn {
+	c := &TCPConn{
+		state:  newConnState(),
+		socket: socket,
+		laddr:  &net.TCPAddr{},
+		raddr:  &net.TCPAddr{},
+	}
+	c.in = NewInputStreamContext(c.state.ctx, in)
+	c.out = NewOutputStreamContext(c.state.ctx, out)
+	if addr, _, isErr := socket.LocalAddress().Result(); !isErr {
+		c.laddr.IP, c.laddr.Port = fromSocketAddress(addr)
+	}
+	if addr, _, isErr := socket.RemoteAddress().Result(); !isErr {
+		c.raddr.IP, c.raddr.Port = fromSocketAddress(addr)
+	}
+	return c
+}
+
+func (c *TCPConn) opError(op string, err error) error {
+	if err == nil || err == io.EOF {
+		return err
+	}
+	return &net.OpError{Op: op, Net: "tcp", Source: c.laddr, Addr: c.raddr, Err: c.state.err(err)}
+}
+
+func (c *TCPConn) Read(p []byte) (int, error) {
+	if !c.state.enter() {
+		return 0, c.opError("read", net.ErrClosed)
+	}
+	defer c.state.leave()
+	n, err := c.in.Read(p)
+	return n, c.opError("read", err)
+}
+
+func (c *TCPConn) Write(p []byte) (int, error) {
+	if !c.state.enter() {
+		return 0, c.opError("write", net.ErrClosed)
+	}
+	defer c.state.leave()
+	n, err := c.out.Write(p)
+	return n, c.opError("write", err)
+}
+
+// ReadFrom 实现 io.ReaderFrom，来源为宿主流或 TCPConn 时直接 splice
+func (c *TCPConn) ReadFrom(r io.Reader) (int64, error) {
+	if !c.state.enter() {
+		return 0, c.opError("readfrom", net.ErrClosed)
+	}
+	defer c.state.leave()
+	if src, ok := r.(*TCPConn); ok {
+		if !src.state.enter() {
+			return 0, c.opError("readfrom", net.ErrClosed)
+		}
+		defer src.state.leave()
+		r = &src.in
+	}
+	n, err := c.out.ReadFrom(r)
+	return n, c.opError("readfrom", err)
+}
+
+// WriteTo 实现 io.WriterTo，目标为宿主流或 TCPConn 时直接 splice
+func (c *TCPConn) WriteTo(w io.Writer) (int64, error) {
+	if !c.state.enter() {
+		return 0, c.opError("writeto", net.ErrClosed)
+	}
+	defer c.state.leave()
+	if dst, ok := w.(*TCPConn); ok {
+		if !dst.state.enter() {
+			return 0, c.opError("writeto", net.ErrClosed)
+		}
+		defer dst.state.leave()
+		w = &dst.out
+	}
+	n, err := c.in.WriteTo(w)
+	return n, c.opError("writeto", err)
+}
+
+// Close 关闭连接，阻塞中的读写返回 net.ErrClosed
+// 已写入的数据在写截止时间（未设置时为 closeFlushTimeout）内尽量发送完毕
+func (c *TCPConn) Close() error {
+	if !c.state.close() {
+		return c.opError("close", net.ErrClosed)
+	}
+	c.in.Close()
+
+	// ctx 已取消，改为仅受截止时间限制
+	c.out.ctx = nil
+	if c.out.deadline.get().IsZero() {
+		c.out.SetDeadline(time.Now().Add(closeFlushTimeout))
+	}
+	err := c.out.Close()
+	c.socket.ResourceDrop()
+	if errors.Is(err, io.EOF) {
+		err = nil
+	}
+	return c.opError("close", err)
+}
+
+// CloseRead 关闭读方向
+func (c *TCPConn) CloseRead() error {
+	if !c.state.enter() {
+		return c.opError("close", net.ErrClosed)
+	}
+	defer c.state.leave()
+	if _, code, isErr := c.socket.Shutdown(tcp.ShutdownTypeReceive).Result(); isErr {
+		return c.opError("close", &SocketError{Code: code})
+	}
+	return nil
+}
+
+// CloseWrite 发送完已写入的数据后关闭写方向，对端读取到 EOF
+func (c *TCPConn) CloseWrite() error {
+	if !c.state.enter() {
+		return c.opError("close", net.ErrClosed)
+	}
+	defer c.state.leave()
+	if err := c.out.flush(); err != nil {
+		return c.opError("close", err)
+	}
+	if _, code, isErr := c.socket.Shutdown(tcp.ShutdownTypeSend).Result(); isErr {
+		return c.opError("close", &SocketError{Code: code})
+	}
+	return nil
+}
+
+func (c *TCPConn) LocalAddr() net.Addr {
+	return c.laddr
+}
+
+func (c *TCPConn) RemoteAddr() net.Addr {
+	return c.raddr
+}
+
+func (c *TCPConn) SetDeadline(t time.Time) error {
+	c.in.SetDeadline(t)
+	c.out.SetDeadline(t)
+	return nil
+}
+
+func (c *TCPConn) SetReadDeadline(t time.Time) error {
+	c.in.SetDeadline(t)
+	return nil
+}
+
+func (c *TCPConn) SetWriteDeadline(t time.Time) error {
+	c.out.SetDeadline(t)
+	return nil
+}
+
+// SetKeepAlive 开启或关闭 TCP keep-alive
+func (c *TCPConn) SetKeepAlive(keepalive bool) error {
+	if _, code, isErr := c.socket.SetKeepAliveEnabled(keepalive).Result(); isErr {
+		return c.opError("set", &SocketError{Code: code})
+	}
+	return nil
+}
+
+// SetKeepAlivePeriod 设置 TCP keep-alive 的空闲时间与探测间隔
+func (c *TCPConn) SetKeepAlivePeriod(d time.Duration) error {
+	if _, code, isErr := c.socket.SetKeepAliveIdleTime(tcp.Duration(d)).Result(); isErr {
+		return c.opError("set", &SocketError{Code: code})
+	}
+	if _, code, isErr := c.socket.SetKeepAliveInterval(tcp.Duration(d)).Result(); isErr {
+		return c.opError("set", &SocketError{Code: code})
+	}
+	return nil
+}
+
+// UDPConn 基于 wasi:sockets/udp 的已连接 net.Conn，每次 Read/Write 对应一个数据报
+type UDPConn struct {
+	state  connState
+	socket udp.UDPSocket
+	in     udp.IncomingDatagramStream
+	out    udp.OutgoingDatagramStream
+	// 第一次需要等待时订阅
+	inPollable  poll.Pollable
+	outPollable poll.Pollable
+
+	readDeadline  pollDeadline
+	writeDeadline pollDeadline
+	laddr         *net.UDPAddr
+	raddr         *net.UDPAddr
+}
+
+func newUDPConn(socket udp.UDPSocket, in udp.IncomingDatagramStream, out udp.OutgoingDatagramStream) *UDPConn {
+	c := &UDPConn{
+		state:  newConnState(),
+		socket: socket,
+		in:     in,
+		out:    out,
+		laddr:  &net.UDPAddr{},
+		raddr:  &net.UDPAddr{},
+	}
+	if addr, _, isErr := socket.LocalAddress().Result(); !isErr {
+		c.laddr.IP, c.laddr.Port = fromSocketAddress(addr)
+	}
+	if addr, _, isErr := socket.RemoteAddress().Result(); !isErr {
+		c.raddr.IP, c.raddr.Port = fromSocketAddress(addr)
+	}
+	return c
+}
+
+func (c *UDPConn) opError(op string, err error) error {
+	if err == nil {
+		return nil
+	}
+	return &net.OpError{Op: op, Net: "udp", Source: c.laddr, Addr: c.raddr, Err: c.state.err(err)}
+}
+
+// Read 读取一个数据报，p 不足以容纳时多余部分被丢弃
+func (c *UDPConn) Read(p []byte) (int, error) {
+	if !c.state.enter() {
+		return 0, c.opError("read", net.ErrClosed)
+	}
+	defer c.state.leave()
+
+	for {
+		list, code, isErr := c.in.Receive(1).Result()
+		if isErr {
+			return 0, c.opError("read", &SocketError{Code: code})
+		}
+		datagrams := list.Slice()
+		if len(datagrams) > 0 {
+			data := datagrams[0].Data.Slice()
+			n := copy(p, data)
+			freeWasiSlice(data)
+			freeWasiSlice(datagrams)
+			return n, nil
+		}
+		if c.inPollable == 0 {
+			c.inPollable = c.in.Subscribe()
+		}
+		if err := waitDeadline(c.state.ctx, c.inPollable, &c.readDeadline); err != nil {
+			return 0, c.opError("read", err)
+		}
+	}
+}
+
+// Write 将 p 作为一个数据报发送
+func (c *UDPConn) Write(p []byte) (int, error) {
+	if !c.state.enter() {
+		return 0, c.opError("write", net.ErrClosed)
+	}
+	defer c.state.leave()
+
+	datagrams := []udp.OutgoingDatagram{{Data: cm.ToList(p)}}
+	for {
+		permit, code, isErr := c.out.CheckSend().Result()
+		if isErr {
+			return 0, c.opError("write", &SocketError{Code: code})
+		}
+		if permit > 0 {
+			sent, code, isErr := c.out.Send(cm.ToList(datagrams)).Result()
+			if isErr {
+				return 0, c.opError("write", &SocketError{Code: code})
+			}
+			if sent > 0 {
+				return len(p), nil
+			}
+		}
+		if c.outPollable == 0 {
+			c.outPollable = c.out.Subscribe()
+		}
+		if err := waitDeadline(c.state.ctx, c.outPollable, &c.writeDeadline); err != nil {
+			return 0, c.opError("write", err)
+		}
+	}
+}
+
+func (c *UDPConn) Close() error {
+	if !c.state.close() {
+		return c.opError("close", net.ErrClosed)
+	}
+	if c.inPollable != 0 {
+		c.inPollable.ResourceDrop()
+	}
+	if c.outPollable != 0 {
+		c.outPollable.ResourceDrop()
+	}
+	c.in.ResourceDrop()
+	c.out.ResourceDrop()
+	c.socket.ResourceDrop()
+	return nil
+}
+
+func (c *UDPConn) LocalAddr() net.Addr {
+	return c.laddr
+}
+
+func (c *UDPConn) RemoteAddr() net.Addr {
+	return c.raddr
+}
+
+func (c *UDPConn) SetDeadline(t time.Time) error {
+	c.readDeadline.set(t)
+	c.writeDeadline.set(t)
+	return nil
+}
+
+func (c *UDPConn) SetReadDeadline(t time.Time) error {
+	c.readDeadline.set(t)
+	return nil
+}
+
+func (c *UDPConn) SetWriteDeadline(t time.Time) error {
+	c.writeDeadline.set(t)
+	return nil
+}
+
+// TCPListener 基于 wasi:sockets/tcp 的 net.Listener
+type TCPListener struct {
+	state    connState
+	socket   tcp.TCPSocket
+	laddr    *net.TCPAddr
+	deadline pollDeadline
+}
+
+// Listen 在 address 上监听 TCP 连接，network 支持 tcp、tcp4、tcp6
+// address 的主机部分为空时监听全零地址，端口为 0 时由宿主分配
+func Listen(network, address string) (net.Listener, error) {
+	return ListenContext(context.Background(), network, address)
+}
+
+// ListenContext 同 Listen，ctx 用于域名解析与绑定过程的取消
+func ListenContext(ctx context.Context, network, address string) (net.Listener, error) {
+	proto, family, err := parseNetwork(network)
+	if err == nil && proto != "tcp" {
+		err = net.UnknownNetworkError(network)
+	}
+	if err != nil {
+		return nil, &net.OpError{Op: "listen", Net: network, Err: err}
+	}
+	host, port, err := splitHostPort(address)
+	if err != nil {
+		return nil, &net.OpError{Op: "listen", Net: network, Err: err}
+	}
+	ips, err := resolveAddrs(ctx, host, family, false)
+	if err != nil {
+		return nil, &net.OpError{Op: "listen", Net: network, Err: err}
+	}
+
+	l, err := listenTCP(ctx, ips[0], port)
+	if err != nil {
+		return nil, &net.OpError{Op: "listen", Net: network, Addr: &net.TCPAddr{IP: ips[0], Port: port}, Err: err}
+	}
+	return l, nil
+}
+
+func listenTCP(ctx context.Context, ip net.IP, port int) (*TCPListener, error) {
+	local, family := toSocketAddress(ip, port)
+	socket, code, isErr := tcpcreatesocket.CreateTCPSocket(family).Result()
+	if isErr {
+		return nil, &SocketError{Code: code}
+	}
+
+	err := func() error {
+		if _, code, isErr := socket.StartBind(defaultNetwork(), local).Result(); isErr {
+			return &SocketError{Code: code}
+		}
+		if _, err := awaitSocket(ctx, nil, socket.Subscribe, func() (struct{}, network.ErrorCode, bool) {
+			return socket.FinishBind().Result()
+		}); err != nil {
+			return err
+		}
+		if _, code, isErr := socket.StartListen().Result(); isErr {
+			return &SocketError{Code: code}
+		}
+		_, err := awaitSocket(ctx, nil, socket.Subscribe, func() (struct{}, network.ErrorCode, bool) {
+			return socket.FinishListen().Result()
+		})
+		return err
+	}()
+	if err != nil {
+		socket.ResourceDrop()
+		return nil, err
+	}
+
+	l := &TCPListener{state: newConnState(), socket: socket, laddr: &net.TCPAddr{}}
+	if addr, _, isErr := socket.LocalAddress().Result(); isErr {
+		l.laddr.IP, l.laddr.Port = ip, port
+	} else {
+		l.laddr.IP, l.laddr.Port = fromSocketAddress(addr)
+	}
+	return l, nil
+}
+
+func (l *TCPListener) Accept() (net.Conn, error) {
+	if !l.state.enter() {
+		return nil, l.opError(net.ErrClosed)
+	}
+	defer l.state.leave()
+
+	accepted, err := awaitSocket(l.state.ctx, &l.deadline, l.socket.Subscribe, func() (cm.Tuple3[tcp.TCPSocket, tcp.InputStream, tcp.OutputStream], network.ErrorCode, bool) {
+		return l.socket.Accept().Result()
+	})
+	if err != nil {
+		return nil, l.opError(err)
+	}
+	return newTCPConn(accepted.F0, accepted.F1, accepted.F2), nil
+}
+
+func (l *TCPListener) opError(err error) error {
+	return &net.OpError{Op: "accept", Net: "tcp", Addr: l.laddr, Err: l.state.err(err)}
+}
+
+// Close 停止监听，阻塞中的 Accept 返回 net.ErrClosed，已建立的连接不受影响
+func (l *TCPListener) Close() error {
+	if !l.state.close() {
+		return &net.OpError{Op: "close", Net: "tcp", Addr: l.laddr, Err: net.ErrClosed}
+	}
+	l.socket.ResourceDrop()
+	return nil
+}
+
+func (l *TCPListener) Addr() net.Addr {
+	return l.laddr
+}
+
+// SetDeadline 设置 Accept 的截止时间，零值表示不限制，对正在等待中的 Accept 同样生效
+func (l *TCPListener) SetDeadline(t time.Time) error {
+	l.deadline.set(t)
+	return nil
+}
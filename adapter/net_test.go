@@ -0,0 +1,233 @@
+package adapter
+
+import (
+	"net"
+	"testing"
+
+	"github.com/OpenListTeam/openlist-wasi-plugin-driver/binding/wasi/sockets/network"
+)
+
+func TestParseNetwork(t *testing.T) {
+	ipv4, ipv6 := network.IPAddressFamilyIPv4, network.IPAddressFamilyIPv6
+	tests := []struct {
+		name   string
+		proto  string
+		family *network.IPAddressFamily
+		err    bool
+	}{
+		{name: "tcp", proto: "tcp"},
+		{name: "tcp4", proto: "tcp", family: &ipv4},
+		{name: "tcp6", proto: "tcp", family: &ipv6},
+		{name: "udp", proto: "udp"},
+		{name: "udp4", proto: "udp", family: &ipv4},
+		{name: "udp6", proto: "udp", family: &ipv6},
+		{name: "", err: true},
+		{name: "ip", err: true},
+		{name: "tcp5", err: true},
+		{name: "udp46", err: true},
+		{name: "unix", err: true},
+	}
+	for _, tt := range tests {
+		proto, family, err := parseNetwork(tt.name)
+		if tt.err {
+			if _, ok := err.(net.UnknownNetworkError); !ok {
+				t.Errorf("parseNetwork(%q) error = %v, want net.UnknownNetworkError", tt.name, err)
+			}
+			continue
+		}
+		if err != nil {
+			t.Errorf("parseNetwork(%q) error = %v", tt.name, err)
+			continue
+		}
+		if proto != tt.proto {
+			t.Errorf("parseNetwork(%q) proto = %q, want %q", tt.name, proto, tt.proto)
+		}
+		if (family == nil) != (tt.family == nil) || family != nil && *family != *tt.family {
+			t.Errorf("parseNetwork(%q) family = %v, want %v", tt.name, family, tt.family)
+		}
+	}
+}
+
+func TestSplitHostPort(t *testing.T) {
+	tests := []struct {
+		address string
+		host    string
+		port    int
+		err     bool
+	}{
+		{address: "example.com:80", host: "example.com", port: 80},
+		{address: "127.0.0.1:0", host: "127.0.0.1", port: 0},
+		{address: "[::1]:443", host: "::1", port: 443},
+		{address: ":8080", host: "", port: 8080},
+		{address: "example.com:65535", host: "example.com", port: 65535},
+		{address: "example.com:65536", err: true},
+		{address: "example.com:-1", err: true},
+		{address: "example.com:http", err: true},
+		{address: "example.com", err: true},
+		{address: "::1:80", err: true},
+	}
+	for _, tt := range tests {
+		host, port, err := splitHostPort(tt.address)
+		if tt.err {
+			if err == nil {
+				t.Errorf("splitHostPort(%q) = %q, %d, want error", tt.address, host, port)
+			}
+			continue
+		}
+		if err != nil {
+			t.Errorf("splitHostPort(%q) error = %v", tt.address, err)
+			continue
+		}
+		if host != tt.host || port != tt.port {
+			t.Errorf("splitHostPort(%q) = %q, %d, want %q, %d", tt.address, host, port, tt.host, tt.port)
+		}
+	}
+}
+
+func TestSplitHostPortInvalidPort(t *testing.T) {
+	_, _, err := splitHostPort("example.com:http")
+	aerr, ok := err.(*net.AddrError)
+	if !ok || aerr.Err != "invalid port" {
+		t.Fatalf("splitHostPort error = %#v, want invalid port *net.AddrError", err)
+	}
+}
+
+func TestSocketAddress(t *testing.T) {
+	tests := []struct {
+		ip     net.IP
+		port   int
+		family network.IPAddressFamily
+		v4     network.IPv4Address
+		v6     network.IPv6Address
+	}{
+		{
+			ip:     net.IPv4(127, 0, 0, 1),
+			port:   80,
+			family: network.IPAddressFamilyIPv4,
+			v4:     network.IPv4Address{127, 0, 0, 1},
+		},
+		{
+			ip:     net.IP{192, 168, 1, 2},
+			port:   65535,
+			family: network.IPAddressFamilyIPv4,
+			v4:     network.IPv4Address{192, 168, 1, 2},
+		},
+		{
+			// IPv4 映射的 IPv6 地址按 IPv4 处理
+			ip:     net.ParseIP("::ffff:10.0.0.1"),
+			port:   443,
+			family: network.IPAddressFamilyIPv4,
+			v4:     network.IPv4Address{10, 0, 0, 1},
+		},
+		{
+			ip:     net.ParseIP("2001:db8::1"),
+			port:   8080,
+			family: network.IPAddressFamilyIPv6,
+			v6:     network.IPv6Address{0x2001, 0xdb8, 0, 0, 0, 0, 0, 1},
+		},
+		{
+			// nil 按 IPv4 全零地址处理
+			ip:     nil,
+			port:   8080,
+			family: network.IPAddressFamilyIPv4,
+			v4:     network.IPv4Address{0, 0, 0, 0},
+		},
+		{
+			ip:     net.IPv6loopback,
+			port:   0,
+			family: network.IPAddressFamilyIPv6,
+			v6:     network.IPv6Address{0, 0, 0, 0, 0, 0, 0, 1},
+		},
+	}
+	for _, tt := range tests {
+		addr, family := toSocketAddress(tt.ip, tt.port)
+		if family != tt.family {
+			t.Errorf("toSocketAddress(%v) family = %v, want %v", tt.ip, family, tt.family)
+			continue
+		}
+		if family == network.IPAddressFamilyIPv4 {
+			v4 := addr.IPv4()
+			if v4 == nil || v4.Address != tt.v4 || int(v4.Port) != tt.port {
+				t.Errorf("toSocketAddress(%v) = %+v, want %v port %d", tt.ip, v4, tt.v4, tt.port)
+			}
+		} else {
+			v6 := addr.IPv6()
+			if v6 == nil || v6.Address != tt.v6 || int(v6.Port) != tt.port {
+				t.Errorf("toSocketAddress(%v) = %+v, want %v port %d", tt.ip, v6, tt.v6, tt.port)
+			}
+		}
+
+		want := tt.ip
+		if want == nil {
+			want = net.IPv4zero
+		}
+		ip, port := fromSocketAddress(addr)
+		if !ip.Equal(want) || port != tt.port {
+			t.Errorf("fromSocketAddress(toSocketAddress(%v, %d)) = %v, %d", tt.ip, tt.port, ip, port)
+		}
+	}
+}
+
+func TestEmptyHostIP(t *testing.T) {
+	ipv4, ipv6 := network.IPAddressFamilyIPv4, network.IPAddressFamilyIPv6
+	tests := []struct {
+		family *network.IPAddressFamily
+		dial   bool
+		want   net.IP
+	}{
+		{dial: true, want: net.IPv4(127, 0, 0, 1)},
+		{family: &ipv4, dial: true, want: net.IPv4(127, 0, 0, 1)},
+		{family: &ipv6, dial: true, want: net.IPv6loopback},
+		{want: net.IPv4zero},
+		{family: &ipv4, want: net.IPv4zero},
+		{family: &ipv6, want: net.IPv6unspecified},
+	}
+	for _, tt := range tests {
+		if got := emptyHostIP(tt.family, tt.dial); !got.Equal(tt.want) {
+			t.Errorf("emptyHostIP(%v, %v) = %v, want %v", tt.family, tt.dial, got, tt.want)
+		}
+	}
+}
+
+func TestFilterFamily(t *testing.T) {
+	ipv4, ipv6 := network.IPAddressFamilyIPv4, network.IPAddressFamilyIPv6
+	v4 := net.IPv4(10, 0, 0, 1)
+	mapped := net.ParseIP("::ffff:10.0.0.2")
+	v6 := net.ParseIP("2001:db8::1")
+	tests := []struct {
+		ips    []net.IP
+		family *network.IPAddressFamily
+		want   []net.IP
+	}{
+		{ips: []net.IP{v4, v6}, want: []net.IP{v4, v6}},
+		{ips: []net.IP{v4, v6, mapped}, family: &ipv4, want: []net.IP{v4, mapped}},
+		{ips: []net.IP{v4, v6, mapped}, family: &ipv6, want: []net.IP{v6}},
+		{ips: []net.IP{v6}, family: &ipv4},
+		{ips: []net.IP{v4, mapped}, family: &ipv6},
+	}
+	for _, tt := range tests {
+		in := append([]net.IP(nil), tt.ips...)
+		got, err := filterFamily("example.com", in, tt.family)
+		if tt.want == nil {
+			aerr, ok := err.(*net.AddrError)
+			if !ok || aerr.Err != "no suitable address found" || aerr.Addr != "example.com" {
+				t.Errorf("filterFamily(%v, %v) = %v, %v, want no suitable address", tt.ips, tt.family, got, err)
+			}
+			continue
+		}
+		if err != nil {
+			t.Errorf("filterFamily(%v, %v) error = %v", tt.ips, tt.family, err)
+			continue
+		}
+		if len(got) != len(tt.want) {
+			t.Errorf("filterFamily(%v, %v) = %v, want %v", tt.ips, tt.family, got, tt.want)
+			continue
+		}
+		for i := range got {
+			if !got[i].Equal(tt.want[i]) {
+				t.Errorf("filterFamily(%v, %v) = %v, want %v", tt.ips, tt.family, got, tt.want)
+				break
+			}
+		}
+	}
+}
@@ -0,0 +1,320 @@
+//go:build wasip2
+
+// 回环测试需要宿主提供 wasi:sockets，使用 tinygo 编译后通过 wasmtime 运行，
+// driver-test 在 driver 的基础上导出 wasi:cli/run，宿主接口以陷阱代替：
+//
+//	tinygo test -c -target=wasip2 -wit-package ./wit -wit-world driver-test -o adapter.test.wasm ./adapter/
+//	wasmtime run -S inherit-network -S allow-ip-name-lookup -W unknown-imports-trap adapter.test.wasm -test.v
+
+package adapter
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net"
+	"os"
+	"strconv"
+	"testing"
+	"time"
+)
+
+const loopbackTimeout = 5 * time.Second
+
+func listenLoopback(t *testing.T) net.Listener {
+	t.Helper()
+	l, err := Listen("tcp4", "127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("Listen: %v", err)
+	}
+	t.Cleanup(func() { l.Close() })
+	return l
+}
+
+func TestDialerTCPLoopback(t *testing.T) {
+	l := listenLoopback(t)
+	laddr := l.Addr().(*net.TCPAddr)
+	if !laddr.IP.Equal(net.IPv4(127, 0, 0, 1)) || laddr.Port == 0 {
+		t.Fatalf("listener address = %v", laddr)
+	}
+
+	// 回显收到的数据，直到对端关闭写方向
+	accepted := make(chan error, 1)
+	go func() {
+		conn, err := l.Accept()
+		if err != nil {
+			accepted <- err
+			return
+		}
+		defer conn.Close()
+		_, err = io.Copy(conn, conn)
+		accepted <- err
+	}()
+
+	d := Dialer{Timeout: loopbackTimeout}
+	conn, err := d.Dial("tcp", laddr.String())
+	if err != nil {
+		t.Fatalf("Dial: %v", err)
+	}
+	defer conn.Close()
+	if raddr := conn.RemoteAddr().(*net.TCPAddr); raddr.Port != laddr.Port {
+		t.Errorf("RemoteAddr = %v, want port %d", raddr, laddr.Port)
+	}
+
+	conn.SetDeadline(time.Now().Add(loopbackTimeout))
+	msg := []byte("hello, wasi sockets")
+	if _, err := conn.Write(msg); err != nil {
+		t.Fatalf("Write: %v", err)
+	}
+	if err := conn.(*TCPConn).CloseWrite(); err != nil {
+		t.Fatalf("CloseWrite: %v", err)
+	}
+	got, err := io.ReadAll(conn)
+	if err != nil {
+		t.Fatalf("ReadAll: %v", err)
+	}
+	if string(got) != string(msg) {
+		t.Errorf("echo = %q, want %q", got, msg)
+	}
+	if err := <-accepted; err != nil {
+		t.Errorf("server: %v", err)
+	}
+}
+
+func TestDialerLocalAddr(t *testing.T) {
+	l := listenLoopback(t)
+	accepted := make(chan net.Conn, 1)
+	go func() {
+		conn, err := l.Accept()
+		if err != nil {
+			close(accepted)
+			return
+		}
+		accepted <- conn
+	}()
+
+	// IP 为空时绑定全零地址，端口由系统分配
+	d := Dialer{Timeout: loopbackTimeout, LocalAddr: &net.TCPAddr{}}
+	conn, err := d.Dial("tcp4", l.Addr().String())
+	if err != nil {
+		t.Fatalf("Dial: %v", err)
+	}
+	defer conn.Close()
+	local := conn.LocalAddr().(*net.TCPAddr)
+	if local.Port == 0 {
+		t.Errorf("LocalAddr = %v, want a bound port", local)
+	}
+
+	server, ok := <-accepted
+	if !ok {
+		t.Fatal("Accept failed")
+	}
+	defer server.Close()
+	if remote := server.RemoteAddr().(*net.TCPAddr); remote.Port != local.Port {
+		t.Errorf("server RemoteAddr = %v, want port %d", remote, local.Port)
+	}
+}
+
+func TestDialerRefused(t *testing.T) {
+	l := listenLoopback(t)
+	addr := l.Addr().String()
+	l.Close()
+
+	d := Dialer{Timeout: loopbackTimeout}
+	if conn, err := d.Dial("tcp", addr); err == nil {
+		conn.Close()
+		t.Fatal("Dial to closed listener succeeded")
+	}
+}
+
+func TestTCPConnSetDeadlineWakesRead(t *testing.T) {
+	l := listenLoopback(t)
+	go func() {
+		conn, err := l.Accept()
+		if err != nil {
+			return
+		}
+		// 不发送任何数据，保持连接直到对端关闭
+		io.Copy(io.Discard, conn)
+		conn.Close()
+	}()
+
+	conn, err := Dial("tcp", l.Addr().String())
+	if err != nil {
+		t.Fatalf("Dial: %v", err)
+	}
+	defer conn.Close()
+
+	done := make(chan error, 1)
+	go func() {
+		_, err := conn.Read(make([]byte, 1))
+		done <- err
+	}()
+	// 让 Read 先进入等待
+	if err := Sleep(context.Background(), 10*time.Millisecond); err != nil {
+		t.Fatal(err)
+	}
+	conn.SetReadDeadline(time.Now())
+
+	timeout := After(loopbackTimeout)
+	select {
+	case err := <-done:
+		if !errors.Is(err, os.ErrDeadlineExceeded) {
+			t.Errorf("Read error = %v, want os.ErrDeadlineExceeded", err)
+		}
+	case <-timeout:
+		t.Fatal("SetReadDeadline did not wake the pending Read")
+	}
+}
+
+func TestTCPConnCloseWakesRead(t *testing.T) {
+	l := listenLoopback(t)
+	go func() {
+		conn, err := l.Accept()
+		if err != nil {
+			return
+		}
+		io.Copy(io.Discard, conn)
+		conn.Close()
+	}()
+
+	conn, err := Dial("tcp", l.Addr().String())
+	if err != nil {
+		t.Fatalf("Dial: %v", err)
+	}
+
+	done := make(chan error, 1)
+	go func() {
+		_, err := conn.Read(make([]byte, 1))
+		done <- err
+	}()
+	if err := Sleep(context.Background(), 10*time.Millisecond); err != nil {
+		t.Fatal(err)
+	}
+	conn.Close()
+
+	timeout := After(loopbackTimeout)
+	select {
+	case err := <-done:
+		if !errors.Is(err, net.ErrClosed) {
+			t.Errorf("Read error = %v, want net.ErrClosed", err)
+		}
+	case <-timeout:
+		t.Fatal("Close did not wake the pending Read")
+	}
+}
+
+func TestTCPListenerDeadline(t *testing.T) {
+	l := listenLoopback(t)
+	tl := l.(*TCPListener)
+
+	tl.SetDeadline(time.Now().Add(-time.Second))
+	if _, err := l.Accept(); !errors.Is(err, os.ErrDeadlineExceeded) {
+		t.Fatalf("Accept after past deadline error = %v, want os.ErrDeadlineExceeded", err)
+	}
+
+	tl.SetDeadline(time.Time{})
+	done := make(chan error, 1)
+	go func() {
+		_, err := l.Accept()
+		done <- err
+	}()
+	if err := Sleep(context.Background(), 10*time.Millisecond); err != nil {
+		t.Fatal(err)
+	}
+	tl.SetDeadline(time.Now())
+
+	timeout := After(loopbackTimeout)
+	select {
+	case err := <-done:
+		if !errors.Is(err, os.ErrDeadlineExceeded) {
+			t.Errorf("Accept error = %v, want os.ErrDeadlineExceeded", err)
+		}
+	case <-timeout:
+		t.Fatal("SetDeadline did not wake the pending Accept")
+	}
+}
+
+// udpPair 返回互相连接的两个 UDPConn
+func udpPair(t *testing.T) (*UDPConn, *UDPConn) {
+	t.Helper()
+	// 先绑定一次得到空闲端口，再在该端口上建立 b
+	probe, err := Dial("udp4", "127.0.0.1:9")
+	if err != nil {
+		t.Fatalf("Dial probe: %v", err)
+	}
+	portB := probe.LocalAddr().(*net.UDPAddr).Port
+	probe.Close()
+
+	a, err := Dial("udp4", net.JoinHostPort("127.0.0.1", strconv.Itoa(portB)))
+	if err != nil {
+		t.Fatalf("Dial a: %v", err)
+	}
+	portA := a.LocalAddr().(*net.UDPAddr).Port
+
+	d := Dialer{LocalAddr: &net.UDPAddr{IP: net.IPv4(127, 0, 0, 1), Port: portB}}
+	b, err := d.Dial("udp4", net.JoinHostPort("127.0.0.1", strconv.Itoa(portA)))
+	if err != nil {
+		a.Close()
+		t.Fatalf("Dial b: %v", err)
+	}
+	t.Cleanup(func() {
+		a.Close()
+		b.Close()
+	})
+	return a.(*UDPConn), b.(*UDPConn)
+}
+
+func TestUDPConnLoopback(t *testing.T) {
+	a, b := udpPair(t)
+	a.SetDeadline(time.Now().Add(loopbackTimeout))
+	b.SetDeadline(time.Now().Add(loopbackTimeout))
+
+	if _, err := a.Write([]byte("ping")); err != nil {
+		t.Fatalf("a.Write: %v", err)
+	}
+	buf := make([]byte, 64)
+	n, err := b.Read(buf)
+	if err != nil {
+		t.Fatalf("b.Read: %v", err)
+	}
+	if string(buf[:n]) != "ping" {
+		t.Errorf("b.Read = %q, want %q", buf[:n], "ping")
+	}
+
+	if _, err := b.Write([]byte("pong")); err != nil {
+		t.Fatalf("b.Write: %v", err)
+	}
+	// 缓冲区不足时多余部分被丢弃
+	n, err = a.Read(buf[:2])
+	if err != nil {
+		t.Fatalf("a.Read: %v", err)
+	}
+	if string(buf[:n]) != "po" {
+		t.Errorf("a.Read = %q, want %q", buf[:n], "po")
+	}
+}
+
+func TestUDPConnSetDeadlineWakesRead(t *testing.T) {
+	_, b := udpPair(t)
+
+	done := make(chan error, 1)
+	go func() {
+		_, err := b.Read(make([]byte, 64))
+		done <- err
+	}()
+	if err := Sleep(context.Background(), 10*time.Millisecond); err != nil {
+		t.Fatal(err)
+	}
+	b.SetDeadline(time.Now())
+
+	timeout := After(loopbackTimeout)
+	select {
+	case err := <-done:
+		if !errors.Is(err, os.ErrDeadlineExceeded) {
+			t.Errorf("Read error = %v, want os.ErrDeadlineExceeded", err)
+		}
+	case <-timeout:
+		t.Fatal("SetDeadline did not wake the pending Read")
+	}
+}
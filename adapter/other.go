@@ -0,0 +1,79 @@
+package adapter
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	drivertypes "github.com/OpenListTeam/openlist-wasi-plugin-driver/binding/openlist/plugin-driver/types"
+)
+
+// OtherArgs 是 other 导出的参数，对应 OpenList 的 model.OtherArgs
+type OtherArgs struct {
+	Obj    drivertypes.Object
+	Method string
+	// 原始的 JSON 参数，使用 Decode 解码到具体类型
+	Data json.RawMessage
+}
+
+// Decode 将 JSON 参数解码到 v，参数为空时不做任何处理
+func (a OtherArgs) Decode(v any) error {
+	if len(a.Data) == 0 {
+		return nil
+	}
+	return json.Unmarshal(a.Data, v)
+}
+
+// MarshalOtherResult 将 Other 的返回值编码为 JSON
+// json.RawMessage 与 []byte 原样返回，nil 及空的 json.RawMessage、[]byte 返回 null
+func MarshalOtherResult(v any) ([]byte, error) {
+	switch v := v.(type) {
+	case nil:
+		return []byte("null"), nil
+	case json.RawMessage:
+		return rawOrNull(v), nil
+	case []byte:
+		return rawOrNull(v), nil
+	default:
+		return json.Marshal(v)
+	}
+}
+
+func rawOrNull(data []byte) []byte {
+	if len(data) == 0 {
+		return []byte("null")
+	}
+	return data
+}
+
+type otherHandler func(ctx context.Context, obj drivertypes.Object, data json.RawMessage) (any, error)
+
+// OtherMux 按 method 分发 other 调用，并将参数解码为处理函数声明的类型
+// 驱动可以嵌入 OtherMux 来实现 Other 接口
+type OtherMux struct {
+	handlers map[string]otherHandler
+}
+
+// HandleOther 注册 method 的处理函数，参数会被解码为 T
+func HandleOther[T any](m *OtherMux, method string, fn func(ctx context.Context, obj drivertypes.Object, args T) (any, error)) {
+	if m.handlers == nil {
+		m.handlers = make(map[string]otherHandler)
+	}
+	m.handlers[method] = func(ctx context.Context, obj drivertypes.Object, data json.RawMessage) (any, error) {
+		var args T
+		if len(data) > 0 {
+			if err := json.Unmarshal(data, &args); err != nil {
+				return nil, fmt.Errorf("decode args of %s: %w", method, err)
+			}
+		}
+		return fn(ctx, obj, args)
+	}
+}
+
+func (m *OtherMux) Other(ctx context.Context, args OtherArgs) (any, error) {
+	handler, ok := m.handlers[args.Method]
+	if !ok {
+		return nil, fmt.Errorf("%w: method %s", ErrNotSupport, args.Method)
+	}
+	return handler(ctx, args.Obj, args.Data)
+}
@@ -0,0 +1,97 @@
+package adapter
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"strings"
+	"testing"
+
+	drivertypes "github.com/OpenListTeam/openlist-wasi-plugin-driver/binding/openlist/plugin-driver/types"
+)
+
+func TestMarshalOtherResult(t *testing.T) {
+	tests := []struct {
+		v    any
+		want string
+	}{
+		{nil, "null"},
+		{json.RawMessage(nil), "null"},
+		{[]byte{}, "null"},
+		{json.RawMessage(`{"a":1}`), `{"a":1}`},
+		{[]byte(`[1,2]`), `[1,2]`},
+		{map[string]int{"n": 1}, `{"n":1}`},
+		{(*struct{})(nil), "null"},
+		{"text", `"text"`},
+	}
+	for _, tt := range tests {
+		data, err := MarshalOtherResult(tt.v)
+		if err != nil || string(data) != tt.want {
+			t.Errorf("MarshalOtherResult(%#v) = %s, %v, want %s", tt.v, data, err, tt.want)
+		}
+	}
+	if _, err := MarshalOtherResult(make(chan int)); err == nil {
+		t.Error("MarshalOtherResult(chan) succeeded")
+	}
+}
+
+type renameArgs struct {
+	Name string `json:"name"`
+}
+
+func newTestOtherMux() *OtherMux {
+	var m OtherMux
+	HandleOther(&m, "rename", func(ctx context.Context, obj drivertypes.Object, args renameArgs) (any, error) {
+		return obj.Name + " -> " + args.Name, nil
+	})
+	return &m
+}
+
+func TestOtherMux(t *testing.T) {
+	m := newTestOtherMux()
+	obj := drivertypes.Object{Name: "a.txt"}
+
+	got, err := m.Other(context.Background(), OtherArgs{Obj: obj, Method: "rename", Data: json.RawMessage(`{"name":"b.txt"}`)})
+	if err != nil || got != "a.txt -> b.txt" {
+		t.Errorf("rename = %v, %v", got, err)
+	}
+	// 参数为空时处理函数得到零值
+	got, err = m.Other(context.Background(), OtherArgs{Obj: obj, Method: "rename"})
+	if err != nil || got != "a.txt -> " {
+		t.Errorf("rename without args = %v, %v", got, err)
+	}
+}
+
+func TestOtherMuxDecodeError(t *testing.T) {
+	m := newTestOtherMux()
+	_, err := m.Other(context.Background(), OtherArgs{Method: "rename", Data: json.RawMessage(`{"name":1}`)})
+	var typeErr *json.UnmarshalTypeError
+	if !errors.As(err, &typeErr) || !strings.Contains(err.Error(), "decode args of rename") {
+		t.Errorf("error = %v, want a decode error for rename", err)
+	}
+	if _, err := m.Other(context.Background(), OtherArgs{Method: "rename", Data: json.RawMessage(`{`)}); err == nil {
+		t.Error("invalid JSON accepted")
+	}
+}
+
+func TestOtherMuxUnknownMethod(t *testing.T) {
+	for _, m := range []*OtherMux{newTestOtherMux(), {}} {
+		_, err := m.Other(context.Background(), OtherArgs{Method: "delete"})
+		if !errors.Is(err, ErrNotSupport) || !strings.Contains(err.Error(), "delete") {
+			t.Errorf("error = %v, want ErrNotSupport for delete", err)
+		}
+	}
+}
+
+func TestOtherArgsDecode(t *testing.T) {
+	var args renameArgs
+	if err := (OtherArgs{}).Decode(&args); err != nil || args.Name != "" {
+		t.Errorf("Decode(empty) = %+v, %v", args, err)
+	}
+	if err := (OtherArgs{Data: json.RawMessage(`{"name":"x"}`)}).Decode(&args); err != nil || args.Name != "x" {
+		t.Errorf("Decode = %+v, %v", args, err)
+	}
+	if err := (OtherArgs{Data: json.RawMessage(`[`)}).Decode(&args); err == nil {
+		t.Error("Decode(invalid) succeeded")
+	}
+}
@@ -0,0 +1,146 @@
+package adapter
+
+import (
+	"errors"
+	"io"
+	"sync"
+)
+
+// DefaultPeekBlockSize 是 PeekReader 预读缓存的默认大小
+const DefaultPeekBlockSize = 256 << 10
+
+var errNegativeOffset = errors.New("negative offset")
+
+// PeekReader 基于 readable.peek 的随机读取视图，实现 io.ReaderAt、io.Reader 和 io.Seeker
+//
+// 注意宿主端的限制：
+//   - 调用 Streams 后流已被消耗，无法再 peek
+//   - 读取边界超过宿主内部设置的大小后，宿主会缓存整个流
+//   - 流未缓存时 peek 不是线程安全的，因此所有 peek 调用都在同一把锁内串行执行
+type PeekReader struct {
+	req  *UploadRequest
+	size int64
+
+	// 预读缓存大小，小于等于 0 时使用 DefaultPeekBlockSize
+	BlockSize int
+
+	mu       sync.Mutex
+	pos      int64
+	cacheOff int64
+	cache    []byte
+}
+
+// NewPeekReader 创建 PeekReader，内容大小取自 UploadRequest.Object.Size
+func NewPeekReader(req *UploadRequest) *PeekReader {
+	return &PeekReader{req: req, size: req.Object.Size}
+}
+
+// Size 返回内容大小
+func (r *PeekReader) Size() int64 {
+	return r.size
+}
+
+// ReadAt 实现 io.ReaderAt
+func (r *PeekReader) ReadAt(p []byte, off int64) (int, error) {
+	if off < 0 {
+		return 0, errNegativeOffset
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return r.readAt(p, off)
+}
+
+// Read 实现 io.Reader
+func (r *PeekReader) Read(p []byte) (int, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	n, err := r.readAt(p, r.pos)
+	r.pos += int64(n)
+	if err == io.EOF && n > 0 {
+		err = nil
+	}
+	return n, err
+}
+
+// Seek 实现 io.Seeker
+func (r *PeekReader) Seek(offset int64, whence int) (int64, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	switch whence {
+	case io.SeekStart:
+	case io.SeekCurrent:
+		offset += r.pos
+	case io.SeekEnd:
+		offset += r.size
+	default:
+		return 0, errors.New("invalid whence")
+	}
+	if offset < 0 {
+		return 0, errNegativeOffset
+	}
+	r.pos = offset
+	return offset, nil
+}
+
+func (r *PeekReader) readAt(p []byte, off int64) (int, error) {
+	if off >= r.size {
+		return 0, io.EOF
+	}
+	want := min(int64(len(p)), r.size-off)
+	n := 0
+	for int64(n) < want {
+		cur := off + int64(n)
+		// 命中缓存
+		if cur >= r.cacheOff && cur < r.cacheOff+int64(len(r.cache)) {
+			n += copy(p[n:want], r.cache[cur-r.cacheOff:])
+			continue
+		}
+
+		blockSize := r.BlockSize
+		if blockSize <= 0 {
+			blockSize = DefaultPeekBlockSize
+		}
+		// 大块读取直接写入 p，不经过缓存
+		if want-int64(n) >= int64(blockSize) {
+			m, err := r.peek(p[n:want], cur)
+			n += m
+			if err != nil {
+				return n, err
+			}
+			continue
+		}
+
+		length := min(int64(blockSize), r.size-cur)
+		if int64(cap(r.cache)) < length {
+			r.cache = make([]byte, length)
+		}
+		m, err := r.peek(r.cache[:length], cur)
+		r.cacheOff, r.cache = cur, r.cache[:m]
+		if err != nil {
+			r.cache = r.cache[:0]
+			return n, err
+		}
+	}
+	if int64(n) < int64(len(p)) {
+		return n, io.EOF
+	}
+	return n, nil
+}
+
+func (r *PeekReader) peek(p []byte, off int64) (int, error) {
+	stream, err := r.req.Peek(uint64(off), uint64(len(p)))
+	if err != nil {
+		return 0, err
+	}
+	defer stream.Close()
+	n, err := io.ReadFull(stream, p)
+	if err == io.EOF || err == io.ErrUnexpectedEOF {
+		err = io.ErrUnexpectedEOF
+	}
+	return n, err
+}
+
+// SectionReader 返回从 off 开始、长度为 n 的 io.SectionReader
+func (us *UploadRequest) SectionReader(off int64, n int64) *io.SectionReader {
+	return io.NewSectionReader(NewPeekReader(us), off, n)
+}
@@ -0,0 +1,152 @@
+package adapter
+
+import (
+	"io"
+	"sync"
+	"time"
+)
+
+const (
+	// DefaultProgressInterval 两次进度上报之间的最小时间间隔
+	DefaultProgressInterval = 500 * time.Millisecond
+	// DefaultProgressDelta 两次进度上报之间的最小百分比变化
+	DefaultProgressDelta = 1.0
+)
+
+// ProgressTracker 根据已读取字节数与 Object.Size 计算上传进度，并节流调用 UpdateProgress
+// 同一个 ProgressTracker 可以包装多个流，适合分块并发上传
+type ProgressTracker struct {
+	req   uploadSource
+	total int64
+
+	// 最小上报间隔，为 0 时使用 DefaultProgressInterval
+	Interval time.Duration
+	// 最小上报百分比变化，为 0 时使用 DefaultProgressDelta
+	Delta float64
+
+	mu       sync.Mutex
+	read     int64
+	lastTime time.Time
+	lastPct  float64
+}
+
+// NewProgressTracker 创建 ProgressTracker
+func NewProgressTracker(req *UploadRequest) *ProgressTracker {
+	return newProgressTracker(req, req.Object.Size)
+}
+
+func newProgressTracker(req uploadSource, total int64) *ProgressTracker {
+	return &ProgressTracker{req: req, total: total, lastPct: -1}
+}
+
+// Wrap 包装一个流，读取的字节会计入进度
+func (t *ProgressTracker) Wrap(rc io.ReadCloser) *ProgressReader {
+	return &ProgressReader{tracker: t, inner: rc}
+}
+
+// Streams 调用 UploadRequest.Streams 并包装返回的流
+func (t *ProgressTracker) Streams() (io.ReadCloser, error) {
+	return t.wrap(t.req.Streams())
+}
+
+// NextChunk 调用 UploadRequest.NextChunk 并包装返回的流
+// 包装后的流可以直接传给 UploadRequest.ChunkReset，已计入的字节会被回退
+func (t *ProgressTracker) NextChunk() (io.ReadCloser, error) {
+	return t.wrap(t.req.NextChunk())
+}
+
+// Peek 调用 UploadRequest.Peek 并包装返回的流
+func (t *ProgressTracker) Peek(offset uint64, length uint64) (io.ReadCloser, error) {
+	return t.wrap(t.req.Peek(offset, length))
+}
+
+// Done 立即上报 100%
+func (t *ProgressTracker) Done() {
+	t.mu.Lock()
+	t.read = t.total
+	t.lastTime, t.lastPct = time.Now(), 100
+	t.mu.Unlock()
+	t.req.UpdateProgress(100)
+}
+
+func (t *ProgressTracker) wrap(rc io.ReadCloser, err error) (io.ReadCloser, error) {
+	if err != nil {
+		return nil, err
+	}
+	return t.Wrap(rc), nil
+}
+
+func (t *ProgressTracker) add(n int64) {
+	if t.total <= 0 || n == 0 {
+		return
+	}
+
+	t.mu.Lock()
+	t.read = min(max(t.read+n, 0), t.total)
+	pct := float64(t.read) * 100 / float64(t.total)
+
+	interval := t.Interval
+	if interval <= 0 {
+		interval = DefaultProgressInterval
+	}
+	delta := t.Delta
+	if delta <= 0 {
+		delta = DefaultProgressDelta
+	}
+	now := time.Now()
+	diff := pct - t.lastPct
+	if diff < 0 {
+		diff = -diff
+	}
+	// 完成时总是上报，其余情况需要同时满足时间和变化量
+	report := pct == 100 && t.lastPct != 100 ||
+		diff >= delta && now.Sub(t.lastTime) >= interval
+	if report {
+		t.lastTime, t.lastPct = now, pct
+	}
+	t.mu.Unlock()
+
+	if report {
+		t.req.UpdateProgress(pct)
+	}
+}
+
+// ProgressReader 是 ProgressTracker 包装后的流
+type ProgressReader struct {
+	tracker *ProgressTracker
+	inner   io.ReadCloser
+	read    int64
+}
+
+func (r *ProgressReader) Read(p []byte) (int, error) {
+	n, err := r.inner.Read(p)
+	if n > 0 {
+		r.read += int64(n)
+		r.tracker.add(int64(n))
+	}
+	return n, err
+}
+
+func (r *ProgressReader) Close() error {
+	return r.inner.Close()
+}
+
+// Unwrap 返回被包装的流
+func (r *ProgressReader) Unwrap() io.ReadCloser {
+	return r.inner
+}
+
+// rollback 回退该流已计入的字节，用于分块重新读取
+func (r *ProgressReader) rollback() {
+	r.tracker.add(-r.read)
+	r.read = 0
+}
+
+// rollbackChunk 供 chunk-reset 使用，chunk 为 ProgressReader 时回退其进度并返回被包装的流
+func rollbackChunk(chunk io.ReadCloser) io.ReadCloser {
+	if pr, ok := chunk.(*ProgressReader); ok {
+		pr.rollback()
+		return pr.Unwrap()
+	}
+	return chunk
+}
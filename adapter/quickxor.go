@@ -0,0 +1,55 @@
+package adapter
+
+import (
+	"encoding/binary"
+	"hash"
+)
+
+const (
+	quickXorSize  = 20
+	quickXorWidth = quickXorSize * 8
+	quickXorShift = 11
+)
+
+// quickXor OneDrive QuickXorHash
+// 第 i 个字节循环左移 i*11 位后异或进 160 位状态，最后将总长度按小端异或进末尾 8 字节
+type quickXor struct {
+	state  [quickXorSize]byte
+	length uint64
+}
+
+// NewQuickXor 创建 OneDrive QuickXorHash
+// OneDrive 接口使用 base64 编码，MultiHasher.Sum 给出的十六进制需经 HashValBase64 转换
+func NewQuickXor() hash.Hash {
+	return &quickXor{}
+}
+
+func (q *quickXor) Write(p []byte) (int, error) {
+	bit := int(q.length * quickXorShift % quickXorWidth)
+	for _, c := range p {
+		idx, shift := bit/8, bit%8
+		v := uint16(c) << shift
+		q.state[idx] ^= byte(v)
+		q.state[(idx+1)%quickXorSize] ^= byte(v >> 8)
+		bit = (bit + quickXorShift) % quickXorWidth
+	}
+	q.length += uint64(len(p))
+	return len(p), nil
+}
+
+func (q *quickXor) Sum(b []byte) []byte {
+	out := q.state
+	var length [8]byte
+	binary.LittleEndian.PutUint64(length[:], q.length)
+	for i, c := range length {
+		out[quickXorSize-8+i] ^= c
+	}
+	return append(b, out[:]...)
+}
+
+func (q *quickXor) Reset() {
+	*q = quickXor{}
+}
+
+func (q *quickXor) Size() int      { return quickXorSize }
+func (q *quickXor) BlockSize() int { return 64 }
@@ -0,0 +1,88 @@
+package adapter
+
+import (
+	"context"
+
+	drivertypes "github.com/OpenListTeam/openlist-wasi-plugin-driver/binding/openlist/plugin-driver/types"
+)
+
+// RapidPath 表示 RapidPut 最终采用的上传方式
+type RapidPath uint8
+
+const (
+	// RapidPathInstant 秒传成功，没有传输文件内容
+	RapidPathInstant RapidPath = iota
+	// RapidPathStream 秒传失败，回退到流式上传
+	RapidPathStream
+)
+
+func (p RapidPath) String() string {
+	switch p {
+	case RapidPathInstant:
+		return "instant"
+	case RapidPathStream:
+		return "stream"
+	}
+	return "unknown"
+}
+
+// RapidFunc 尝试秒传，服务端不存在相同内容时返回 ok 为 false
+type RapidFunc[T any] func(ctx context.Context, hashes []drivertypes.HashInfo) (obj T, ok bool, err error)
+
+// StreamPutFunc 流式上传，hashes 与传给 RapidFunc 的相同，可用于最终的提交请求
+type StreamPutFunc[T any] func(ctx context.Context, hashes []drivertypes.HashInfo) (obj T, err error)
+
+// RapidPut 秒传辅助函数
+// 先从 Object.Hashes 中查找所需的哈希，缺少的部分通过 get-hasher 一次性计算（宿主会缓存整个文件）
+// 然后尝试秒传，失败时回退到 fallback 流式上传
+func RapidPut[T any](ctx context.Context, req *UploadRequest, algs []drivertypes.HashAlg, try RapidFunc[T], fallback StreamPutFunc[T]) (obj T, path RapidPath, err error) {
+	hashes, err := req.Hashes(algs)
+	if err != nil {
+		return obj, RapidPathStream, err
+	}
+
+	obj, ok, err := try(ctx, hashes)
+	if err != nil {
+		return obj, RapidPathInstant, err
+	}
+	if ok {
+		return obj, RapidPathInstant, nil
+	}
+
+	obj, err = fallback(ctx, hashes)
+	return obj, RapidPathStream, err
+}
+
+// Hashes 按 algs 的顺序返回文件哈希，优先使用 Object.Hashes，缺少的部分通过 get-hasher 计算
+func (us *UploadRequest) Hashes(algs []drivertypes.HashAlg) ([]drivertypes.HashInfo, error) {
+	known := make(map[HashAlgKey]string)
+	for _, info := range us.Object.Hashes.Slice() {
+		if info.Val != "" {
+			known[KeyOfHashAlg(info.Alg)] = info.Val
+		}
+	}
+
+	var missing []drivertypes.HashAlg
+	for _, alg := range algs {
+		if _, ok := known[KeyOfHashAlg(alg)]; !ok {
+			missing = append(missing, alg)
+		}
+	}
+	if len(missing) > 0 {
+		infos, err := us.GetHash(missing)
+		if err != nil {
+			return nil, err
+		}
+		for _, info := range infos {
+			known[KeyOfHashAlg(info.Alg)] = info.Val
+		}
+	}
+
+	hashes := make([]drivertypes.HashInfo, 0, len(algs))
+	for _, alg := range algs {
+		if val, ok := known[KeyOfHashAlg(alg)]; ok {
+			hashes = append(hashes, drivertypes.HashInfo{Alg: alg, Val: val})
+		}
+	}
+	return hashes, nil
+}
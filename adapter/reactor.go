@@ -0,0 +1,314 @@
+package adapter
+
+import (
+	"context"
+	"errors"
+	"os"
+	"runtime"
+	"sync"
+	"time"
+
+	monotonicclock "github.com/OpenListTeam/openlist-wasi-plugin-driver/binding/wasi/clocks/monotonic-clock"
+	"github.com/OpenListTeam/openlist-wasi-plugin-driver/binding/wasi/io/poll"
+	"go.bytecodealliance.org/cm"
+)
+
+// 空闲时单次 poll 的超时从 reactorIdleMin 开始逐次翻倍，最长 reactorIdleMax
+// poll 期间整个实例都被阻塞，没有被 reactor 察觉的可运行 goroutine 和 Go 的定时器最多延迟 reactorIdleMax
+// 宿主的取消同样在 reactorIdleMax 内察觉
+const (
+	reactorIdleMin = time.Millisecond
+	reactorIdleMax = 200 * time.Millisecond
+)
+
+// reactor 通过 wasi:io/poll.poll 同时等待所有已注册的 pollable，就绪后调用对应的回调
+//
+// NOTE: poll 会阻塞整个 tinygo 实例，因此同一时刻只有一个 goroutine 执行 poll：
+//   - 在 waitPollable 中等待的 goroutine 自己执行 poll，其余等待者挂起在 round 上，不占用调度
+//   - poll 之前先让出调度；自上一轮以来有注册、注销或触发时说明还有 goroutine 在推进，以零超时 poll，
+//     否则阻塞直到某个 pollable 就绪（截止时间本身也是已注册的 pollable），超时按空闲轮数退避
+//   - 只剩回调等待者（Timer、Ticker、宿主的 cancellable）而没有 goroutine 在等待时，由 reactor goroutine 代为 poll
+type reactor struct {
+	mu      sync.Mutex
+	nextID  uint64
+	waiters map[uint64]reactorWaiter
+	waiting int // 正在 waitPollable 中等待的 goroutine 数量
+	polling bool
+	round   chan struct{} // 当前一轮 poll 结束时关闭
+	// activity 在注册、注销和触发时递增，seen 为上一轮 poll 结束时的值
+	activity, seen uint64
+	idle           time.Duration
+	wake           chan struct{}
+	start          sync.Once
+}
+
+type reactorWaiter struct {
+	pollable poll.Pollable
+	ready    func()
+}
+
+var defaultReactor = &reactor{
+	waiters: make(map[uint64]reactorWaiter),
+	round:   make(chan struct{}),
+	wake:    make(chan struct{}, 1),
+}
+
+// register 注册 p，就绪时调用一次 ready，之后自动注销
+// ready 在执行 poll 的 goroutine 中调用，不能阻塞；调用方在释放 p 之前必须先调用 unregister
+func (r *reactor) register(p poll.Pollable, ready func()) uint64 {
+	r.start.Do(func() { go r.run() })
+	r.mu.Lock()
+	r.nextID++
+	id := r.nextID
+	r.waiters[id] = reactorWaiter{pollable: p, ready: ready}
+	r.activity++
+	r.mu.Unlock()
+	r.signal()
+	return id
+}
+
+// unregister 注销等待者，已经触发或已注销时什么也不做
+func (r *reactor) unregister(id uint64) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if _, ok := r.waiters[id]; ok {
+		delete(r.waiters, id)
+		r.activity++
+	}
+}
+
+// signal 唤醒 reactor goroutine 重新检查是否需要代为 poll
+func (r *reactor) signal() {
+	select {
+	case r.wake <- struct{}{}:
+	default:
+	}
+}
+
+// run 在没有 goroutine 等待但仍有回调等待者时代为 poll
+func (r *reactor) run() {
+	for {
+		r.mu.Lock()
+		for r.polling || r.waiting > 0 || len(r.waiters) == 0 {
+			r.mu.Unlock()
+			<-r.wake
+			r.mu.Lock()
+		}
+		r.polling = true
+		r.mu.Unlock()
+		r.pollOnce()
+	}
+}
+
+// acquire 尝试成为本轮 poll 的执行者，失败时返回当前一轮结束时关闭的 channel
+func (r *reactor) acquire() (<-chan struct{}, bool) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if r.polling {
+		return r.round, false
+	}
+	r.polling = true
+	return nil, true
+}
+
+// pollOnce 执行一轮 poll 并调用就绪等待者的回调，调用前必须已通过 acquire 或 run 取得 polling
+func (r *reactor) pollOnce() {
+	runtime.Gosched()
+
+	r.mu.Lock()
+	var timeout time.Duration
+	if r.activity == r.seen {
+		r.idle = min(max(r.idle*2, reactorIdleMin), reactorIdleMax)
+		timeout = r.idle
+	} else {
+		r.idle = 0
+	}
+	ids := make([]uint64, 0, len(r.waiters)+1)
+	pollables := make([]poll.Pollable, 0, len(r.waiters)+1)
+	for id, w := range r.waiters {
+		ids = append(ids, id)
+		pollables = append(pollables, w.pollable)
+	}
+	r.mu.Unlock()
+
+	timer := monotonicclock.SubscribeDuration(monotonicclock.Duration(timeout))
+	pollables = append(pollables, timer)
+	ready := cloneSliceAndFree(poll.Poll(cm.ToList(pollables)).Slice())
+	timer.ResourceDrop()
+
+	var fire []func()
+	r.mu.Lock()
+	for _, i := range ready {
+		if int(i) >= len(ids) {
+			continue
+		}
+		if w, ok := r.waiters[ids[i]]; ok {
+			delete(r.waiters, ids[i])
+			fire = append(fire, w.ready)
+		}
+	}
+	r.seen = r.activity
+	if len(fire) > 0 {
+		r.activity++
+	}
+	r.polling = false
+	close(r.round)
+	r.round = make(chan struct{})
+	r.mu.Unlock()
+	r.signal()
+
+	for _, fn := range fire {
+		fn()
+	}
+}
+
+// pollDeadline 可在等待期间修改的截止时间
+// 修改后正在等待的 goroutine 立即被唤醒并按新的截止时间重新等待，与 net.Conn 的 SetDeadline 语义一致
+type pollDeadline struct {
+	mu      sync.Mutex
+	t       time.Time
+	changed chan struct{}
+}
+
+func (d *pollDeadline) set(t time.Time) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	d.t = t
+	if d.changed != nil {
+		close(d.changed)
+		d.changed = nil
+	}
+}
+
+func (d *pollDeadline) get() time.Time {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	return d.t
+}
+
+// watch 返回当前的截止时间和在下一次修改时关闭的 channel
+func (d *pollDeadline) watch() (time.Time, <-chan struct{}) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	if d.changed == nil {
+		d.changed = make(chan struct{})
+	}
+	return d.t, d.changed
+}
+
+// errDeadlineChanged 等待期间截止时间被修改
+var errDeadlineChanged = errors.New("deadline changed")
+
+// waitStream 等待流的 pollable 就绪，ctx 为 nil 时不响应取消
+func waitStream(ctx context.Context, p poll.Pollable, deadlines ...*pollDeadline) error {
+	if ctx == nil {
+		ctx = context.Background()
+	}
+	return waitDeadline(ctx, p, deadlines...)
+}
+
+// waitDeadline 同 waitPollable，以 deadlines 中最早的截止时间为准，nil 表示不限制
+// 任一截止时间在等待期间被修改时按新的值重新等待，deadlines 最多两个
+func waitDeadline(ctx context.Context, p poll.Pollable, deadlines ...*pollDeadline) error {
+	for {
+		var (
+			deadline time.Time
+			changed  [2]<-chan struct{}
+		)
+		for i, d := range deadlines {
+			if d == nil {
+				continue
+			}
+			t, c := d.watch()
+			if deadline.IsZero() || !t.IsZero() && t.Before(deadline) {
+				deadline = t
+			}
+			changed[i] = c
+		}
+		if err := waitPollableChanged(ctx, p, deadline, changed); err != errDeadlineChanged {
+			return err
+		}
+	}
+}
+
+// waitPollable 通过 reactor 等待 p 就绪
+// ctx 取消时返回 ctx.Err()，超过 deadline 时返回 os.ErrDeadlineExceeded，deadline 为零值时不限制
+// ctx 的截止时间同样通过 monotonic-clock 等待，不依赖 Go 的定时器
+func waitPollable(ctx context.Context, p poll.Pollable, deadline time.Time) error {
+	return waitPollableChanged(ctx, p, deadline, [2]<-chan struct{}{})
+}
+
+// waitPollableChanged 同 waitPollable，changed 中任一 channel 关闭时返回 errDeadlineChanged
+func waitPollableChanged(ctx context.Context, p poll.Pollable, deadline time.Time, changed [2]<-chan struct{}) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+
+	r := defaultReactor
+	r.mu.Lock()
+	r.waiting++
+	r.mu.Unlock()
+	defer func() {
+		r.mu.Lock()
+		r.waiting--
+		r.mu.Unlock()
+		r.signal()
+	}()
+
+	ready := make(chan struct{})
+	id := r.register(p, func() { close(ready) })
+	defer r.unregister(id)
+
+	var timeout chan struct{}
+	timeoutErr := os.ErrDeadlineExceeded
+	if d, ok := ctx.Deadline(); ok && (deadline.IsZero() || d.Before(deadline)) {
+		deadline, timeoutErr = d, context.DeadlineExceeded
+	}
+	if !deadline.IsZero() {
+		left := time.Until(deadline)
+		if left <= 0 {
+			return timeoutErr
+		}
+		timeout = make(chan struct{})
+		timer := monotonicclock.SubscribeDuration(monotonicclock.Duration(left))
+		timerID := r.register(timer, func() { close(timeout) })
+		defer timer.ResourceDrop()
+		defer r.unregister(timerID)
+	}
+
+	for {
+		// 先检查一次，避免已就绪时再 poll 一轮
+		select {
+		case <-ready:
+			return nil
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-timeout:
+			return timeoutErr
+		case <-changed[0]:
+			return errDeadlineChanged
+		case <-changed[1]:
+			return errDeadlineChanged
+		default:
+		}
+
+		round, poller := r.acquire()
+		if poller {
+			r.pollOnce()
+			continue
+		}
+		select {
+		case <-ready:
+			return nil
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-timeout:
+			return timeoutErr
+		case <-changed[0]:
+			return errDeadlineChanged
+		case <-changed[1]:
+			return errDeadlineChanged
+		case <-round:
+		}
+	}
+}
@@ -0,0 +1,177 @@
+package adapter
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"sync"
+
+	drivertypes "github.com/OpenListTeam/openlist-wasi-plugin-driver/binding/openlist/plugin-driver/types"
+)
+
+// UploadSessionStore 持久化上传会话状态，宿主重启后仍可读取
+// 根包的 DriverHandle 基于宿主导入实现了该接口
+type UploadSessionStore interface {
+	// 会话不存在时返回 false
+	LoadUploadSession(path, hash string) ([]byte, bool, error)
+	SaveUploadSession(path, hash string, state []byte) error
+	RemoveUploadSession(path, hash string) error
+}
+
+// UploadSession 是持久化到宿主的上传会话状态
+type UploadSession struct {
+	ChunkSize uint32 `json:"chunk_size"`
+	// 驱动自定义数据，例如服务端的 upload id
+	Extra map[string]string `json:"extra,omitempty"`
+	// 已完成的分块，按完成顺序排列
+	Parts []UploadPart `json:"parts"`
+}
+
+// ResumableUploader 可恢复的分块上传，分块由 UploadEngine 上传
+// 每完成一个分块就将会话状态保存到宿主，宿主重启后重新上传时会跳过已完成的分块
+type ResumableUploader struct {
+	req   *UploadRequest
+	store UploadSessionStore
+	path  string
+	hash  string
+
+	// 同时上传的分块数量，小于 1 时按 1 处理
+	Concurrency int
+	// 单个分块失败后的重试次数
+	Retries int
+	// 需要为每个分块计算的哈希
+	HashAlgs []drivertypes.HashAlg
+
+	// 保护 session，分块并发完成时更新
+	mu      sync.Mutex
+	session UploadSession
+	resumed bool
+}
+
+// NewResumableUploader 创建可恢复的分块上传，path 为上传目标的完整路径
+// 会话的内容哈希优先取自 req.Object.Hashes，否则通过 GetHash 计算 md5（宿主会缓存整个文件）
+func NewResumableUploader(req *UploadRequest, store UploadSessionStore, path string, chunkSize uint32) (*ResumableUploader, error) {
+	if chunkSize == 0 {
+		return nil, errors.New("chunk size must be greater than 0")
+	}
+
+	hash, err := sessionHash(req)
+	if err != nil {
+		return nil, err
+	}
+
+	u := &ResumableUploader{
+		req:   req,
+		store: store,
+		path:  path,
+		hash:  hash,
+		session: UploadSession{
+			ChunkSize: chunkSize,
+		},
+	}
+
+	data, ok, err := store.LoadUploadSession(path, hash)
+	if err != nil {
+		return nil, err
+	}
+	if ok {
+		var session UploadSession
+		// 状态损坏或分块大小变化时从头开始
+		if json.Unmarshal(data, &session) == nil && session.ChunkSize == chunkSize {
+			u.session = session
+			u.resumed = len(session.Parts) > 0 || len(session.Extra) > 0
+		}
+	}
+	return u, nil
+}
+
+func sessionHash(req *UploadRequest) (string, error) {
+	infos := req.Object.Hashes.Slice()
+	if len(infos) == 0 {
+		var err error
+		infos, err = req.GetHash([]drivertypes.HashAlg{drivertypes.HashAlgMd5()})
+		if err != nil {
+			return "", err
+		}
+		if len(infos) == 0 {
+			return "", errors.New("host returned no hash")
+		}
+	}
+	return HashAlgName(infos[0].Alg) + ":" + infos[0].Val, nil
+}
+
+// Resumed 表示是否从宿主恢复了之前的会话
+func (u *ResumableUploader) Resumed() bool {
+	return u.resumed
+}
+
+// Extra 读取驱动保存的自定义数据
+func (u *ResumableUploader) Extra(key string) (string, bool) {
+	u.mu.Lock()
+	defer u.mu.Unlock()
+	v, ok := u.session.Extra[key]
+	return v, ok
+}
+
+// SetExtra 保存驱动自定义数据并立即持久化，应在上传分块前保存服务端的 upload id
+func (u *ResumableUploader) SetExtra(key, value string) error {
+	u.mu.Lock()
+	defer u.mu.Unlock()
+	if u.session.Extra == nil {
+		u.session.Extra = make(map[string]string)
+	}
+	u.session.Extra[key] = value
+	return u.save()
+}
+
+// Parts 返回已完成的分块，按完成顺序排列
+func (u *ResumableUploader) Parts() []UploadPart {
+	u.mu.Lock()
+	defer u.mu.Unlock()
+	return append([]UploadPart(nil), u.session.Parts...)
+}
+
+// Upload 上传所有未完成的分块，返回按序号排列的全部分块
+// 已完成的分块只通过 next-chunk 读过，不会再次调用 upload，其 Hashes 为空
+func (u *ResumableUploader) Upload(ctx context.Context, upload ChunkUploadFunc) ([]UploadPart, error) {
+	u.mu.Lock()
+	done := make(map[uint32]UploadPart, len(u.session.Parts))
+	for _, part := range u.session.Parts {
+		done[part.Number] = part
+	}
+	u.mu.Unlock()
+
+	engine := UploadEngine{
+		ChunkSize:   u.session.ChunkSize,
+		Concurrency: u.Concurrency,
+		Retries:     u.Retries,
+		HashAlgs:    u.HashAlgs,
+	}
+	skip := func(chunk UploadChunk) (UploadPart, bool) {
+		part, ok := done[chunk.Number]
+		return part, ok
+	}
+	return engine.upload(ctx, u.req, u.req.Object.Size, upload, skip, u.addPart)
+}
+
+// addPart 记录完成的分块并持久化
+func (u *ResumableUploader) addPart(part UploadPart) error {
+	u.mu.Lock()
+	defer u.mu.Unlock()
+	u.session.Parts = append(u.session.Parts, part)
+	return u.save()
+}
+
+// Complete 在驱动完成最终的合并请求后调用，删除宿主中的会话状态
+func (u *ResumableUploader) Complete() error {
+	return u.store.RemoveUploadSession(u.path, u.hash)
+}
+
+// save 持久化会话状态，调用方需持有 u.mu
+func (u *ResumableUploader) save() error {
+	data, err := json.Marshal(u.session)
+	if err != nil {
+		return err
+	}
+	return u.store.SaveUploadSession(u.path, u.hash, data)
+}
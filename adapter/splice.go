@@ -0,0 +1,101 @@
+package adapter
+
+import (
+	"context"
+	"errors"
+	"io"
+)
+
+// spliceSize 单次 splice 的最大字节数
+const spliceSize = 1 << 20
+
+// ReadFrom 实现 io.ReaderFrom
+// r 为宿主的 InputStream 时使用 splice，数据直接在宿主端传输，不经过插件内存
+func (s *OutputStream) ReadFrom(r io.Reader) (int64, error) {
+	if src, ok := r.(*InputStream); ok {
+		return s.splice(src)
+	}
+	// 隐藏 ReadFrom，避免 io.Copy 再次调用自身
+	return io.Copy(struct{ io.Writer }{s}, r)
+}
+
+// WriteTo 实现 io.WriterTo
+// w 为宿主的 OutputStream 时使用 splice，数据直接在宿主端传输，不经过插件内存
+func (s *InputStream) WriteTo(w io.Writer) (int64, error) {
+	if dst, ok := w.(*OutputStream); ok {
+		return dst.splice(s)
+	}
+	// 隐藏 WriteTo，避免 io.Copy 再次调用自身
+	return io.Copy(w, struct{ io.Reader }{s})
+}
+
+// splice 将 src 的内容全部传输到 s，src 读完时返回 nil
+// 没有数据或不可写时通过 reactor 等待
+func (s *OutputStream) splice(src *InputStream) (int64, error) {
+	// 任一端的取消和截止时间对两端的等待都生效
+	ctx, cancel := mergeContext(s.ctx, src.ctx)
+	defer cancel()
+	var total int64
+	for {
+		n, err, iserr := s.inner.Splice(src.inner, spliceSize).Result()
+		if !iserr {
+			total += int64(n)
+			if n == 0 {
+				// 输入端没有数据或输出端不可写
+				if src.pollable == 0 {
+					src.pollable = src.inner.Subscribe()
+				}
+				if err := waitStream(ctx, src.pollable, s.deadline, src.deadline); err != nil {
+					return total, err
+				}
+				if err := waitStream(ctx, s.pollable, s.deadline, src.deadline); err != nil {
+					return total, err
+				}
+			}
+			continue
+		}
+		if detail := err.LastOperationFailed(); detail != nil {
+			defer detail.ResourceDrop()
+			return total, errors.New(detail.ToDebugString())
+		}
+		// closed 可能来自任一端，输出端仍可写时说明是输入端读完了
+		if _, werr, iserr := s.inner.CheckWrite().Result(); iserr {
+			if detail := werr.LastOperationFailed(); detail != nil {
+				detail.ResourceDrop()
+			}
+			return total, io.EOF
+		}
+		return total, nil
+	}
+}
+
+// mergeContext 返回在 a 或 b 取消时都会取消的 ctx，截止时间取两者中较早的
+// 两者都为 nil 时返回 nil，即不响应取消
+func mergeContext(a, b context.Context) (context.Context, context.CancelFunc) {
+	if b == nil || a == b {
+		return a, func() {}
+	}
+	if a == nil {
+		return b, func() {}
+	}
+
+	ctx, cancel := context.WithCancel(a)
+	if d, ok := b.Deadline(); ok {
+		var cancelDeadline context.CancelFunc
+		ctx, cancelDeadline = context.WithDeadline(ctx, d)
+		cancelParent := cancel
+		cancel = func() {
+			cancelDeadline()
+			cancelParent()
+		}
+	}
+	if b.Err() != nil {
+		cancel()
+		return ctx, cancel
+	}
+	stop := context.AfterFunc(b, cancel)
+	return ctx, func() {
+		stop()
+		cancel()
+	}
+}
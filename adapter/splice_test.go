@@ -0,0 +1,63 @@
+package adapter
+
+import (
+	"context"
+	"testing"
+	"time"
+)
+
+func TestMergeContext(t *testing.T) {
+	if ctx, cancel := mergeContext(nil, nil); ctx != nil {
+		t.Error("mergeContext(nil, nil) != nil")
+	} else {
+		cancel()
+	}
+
+	a, cancelA := context.WithCancel(context.Background())
+	defer cancelA()
+	if ctx, cancel := mergeContext(a, nil); ctx != a {
+		t.Error("mergeContext(a, nil) != a")
+	} else {
+		cancel()
+	}
+	if ctx, cancel := mergeContext(nil, a); ctx != a {
+		t.Error("mergeContext(nil, a) != a")
+	} else {
+		cancel()
+	}
+
+	// b 取消时合并后的 ctx 同样取消
+	b, cancelB := context.WithCancel(context.Background())
+	ctx, cancel := mergeContext(a, b)
+	defer cancel()
+	cancelB()
+	select {
+	case <-ctx.Done():
+	case <-time.After(time.Second):
+		t.Fatal("merged ctx not canceled after b")
+	}
+	if a.Err() != nil {
+		t.Error("canceling b canceled a")
+	}
+
+	// 已取消的 b
+	ctx2, cancel2 := mergeContext(a, b)
+	defer cancel2()
+	if ctx2.Err() == nil {
+		t.Error("merged ctx with canceled b is not canceled")
+	}
+
+	// 截止时间取较早者
+	early := time.Now().Add(time.Minute)
+	c, cancelC := context.WithDeadline(context.Background(), early)
+	defer cancelC()
+	ctx3, cancel3 := mergeContext(a, c)
+	defer cancel3()
+	if d, ok := ctx3.Deadline(); !ok || !d.Equal(early) {
+		t.Errorf("deadline = %v, %v, want %v", d, ok, early)
+	}
+	cancelA()
+	if ctx3.Err() == nil {
+		t.Error("merged ctx not canceled after a")
+	}
+}
@@ -1,17 +1,24 @@
 package adapter
 
 import (
+	"context"
 	"errors"
 	"io"
+	"time"
 
 	drivertypes "github.com/OpenListTeam/openlist-wasi-plugin-driver/binding/openlist/plugin-driver/types"
 	"github.com/OpenListTeam/openlist-wasi-plugin-driver/binding/wasi/io/poll"
+	"github.com/OpenListTeam/openlist-wasi-plugin-driver/binding/wasi/io/streams"
 	"go.bytecodealliance.org/cm"
 )
 
 type OutputStream struct {
 	inner    drivertypes.OutputStream
 	pollable poll.Pollable
+
+	// 为 nil 时不响应取消
+	ctx      context.Context
+	deadline *pollDeadline
 }
 
 func NewOutputStream(inner drivertypes.OutputStream) OutputStream {
@@ -19,9 +26,27 @@ func NewOutputStream(inner drivertypes.OutputStream) OutputStream {
 	return OutputStream{
 		inner:    inner,
 		pollable: pollable,
+		deadline: new(pollDeadline),
 	}
 }
 
+// NewOutputStreamContext 创建可取消的 OutputStream，等待可写时 ctx 取消会返回 context.Canceled
+func NewOutputStreamContext(ctx context.Context, inner drivertypes.OutputStream) OutputStream {
+	s := NewOutputStream(inner)
+	s.ctx = ctx
+	return s
+}
+
+// SetDeadline 设置截止时间，超时返回 os.ErrDeadlineExceeded，零值表示不限制
+// 对正在等待中的写入同样生效
+func (s *OutputStream) SetDeadline(t time.Time) {
+	s.deadline.set(t)
+}
+
+func (s *OutputStream) wait() error {
+	return waitStream(s.ctx, s.pollable, s.deadline)
+}
+
 func (s *OutputStream) Write(p []byte) (int, error) {
 	total := len(p)
 	writeSize := total
@@ -31,14 +56,7 @@ func (s *OutputStream) Write(p []byte) (int, error) {
 		for {
 			size, err, iserr := s.inner.CheckWrite().Result()
 			if iserr {
-				switch err.Tag() {
-				case 0:
-					errDetail := err.LastOperationFailed()
-					defer errDetail.ResourceDrop()
-					return total - writeSize, errors.New(errDetail.ToDebugString())
-				case 1:
-					return total - writeSize, io.EOF
-				}
+				return total - writeSize, streamError(err)
 			}
 			if size > 0 {
 				if size < uint64(writeSize) {
@@ -46,20 +64,15 @@ func (s *OutputStream) Write(p []byte) (int, error) {
 				}
 				break
 			}
-			s.pollable.Block()
+			if err := s.wait(); err != nil {
+				return total - writeSize, err
+			}
 		}
 
 		// Write in chunks
 		_, err, iserr := s.inner.Write(cm.ToList(p[:writeSize])).Result()
 		if iserr {
-			switch err.Tag() {
-			case 0:
-				errDetail := err.LastOperationFailed()
-				defer errDetail.ResourceDrop()
-				return total - writeSize, errors.New(errDetail.ToDebugString())
-			case 1:
-				return total - writeSize, io.EOF
-			}
+			return total - writeSize, streamError(err)
 		}
 		p = p[writeSize:]
 		writeSize = len(p)
@@ -71,58 +84,111 @@ func (s *OutputStream) Write(p []byte) (int, error) {
 func (s *OutputStream) Close() error {
 	defer s.inner.ResourceDrop()
 	defer s.pollable.ResourceDrop()
+	return s.flush()
+}
 
-	_, err, iserr := s.inner.BlockingFlush().Result()
-	if iserr {
-		switch err.Tag() {
-		case 0:
-			err := err.LastOperationFailed()
-			defer err.ResourceDrop()
-			return errors.New(err.ToDebugString())
-		case 1:
-			return io.EOF
-		}
+// flush 发起 flush 并通过 reactor 等待已写入的数据全部发出
+func (s *OutputStream) flush() error {
+	// flush 完成后 pollable 就绪
+	if _, err, iserr := s.inner.Flush().Result(); iserr {
+		return streamError(err)
+	}
+	if err := s.wait(); err != nil {
+		return err
+	}
+	if _, err, iserr := s.inner.CheckWrite().Result(); iserr {
+		return streamError(err)
 	}
-
 	return nil
 }
 
 type InputStream struct {
 	inner drivertypes.InputStream
+	// 第一次需要等待时订阅
+	pollable poll.Pollable
+
+	// 为 nil 时不响应取消
+	ctx      context.Context
+	deadline *pollDeadline
+}
+
+// SetDeadline 设置读取的截止时间，超时返回 os.ErrDeadlineExceeded，零值表示不限制
+// 对正在等待中的读取同样生效
+func (s *InputStream) SetDeadline(t time.Time) {
+	s.deadline.set(t)
+}
+
+func (s *InputStream) wait() error {
+	if s.pollable == 0 {
+		s.pollable = s.inner.Subscribe()
+	}
+	return waitStream(s.ctx, s.pollable, s.deadline)
 }
 
 func (s *InputStream) Read(p []byte) (int, error) {
-	data, err, iserr := s.inner.BlockingRead(min(uint64(len(p)), 1<<20)).Result()
-	if iserr {
-		switch err.Tag() {
-		case 0:
-			errDetail := err.LastOperationFailed()
-			defer errDetail.ResourceDrop()
-			return 0, errors.New(errDetail.ToDebugString())
-		case 1:
-			return 0, io.EOF
-		}
+	if len(p) == 0 {
+		return 0, nil
 	}
+	size := min(uint64(len(p)), 1<<20)
 
-	wasiSlice := data.Slice()
-	defer freeWasiSlice(wasiSlice)
+	for {
+		data, err, iserr := s.inner.Read(size).Result()
+		if iserr {
+			return 0, streamError(err)
+		}
 
-	return copy(p, wasiSlice), nil
+		wasiSlice := data.Slice()
+		if len(wasiSlice) > 0 {
+			defer freeWasiSlice(wasiSlice)
+			return copy(p, wasiSlice), nil
+		}
+		if err := s.wait(); err != nil {
+			return 0, err
+		}
+	}
 }
 
 func (s *InputStream) Close() error {
+	if s.pollable != 0 {
+		s.pollable.ResourceDrop()
+	}
 	s.inner.ResourceDrop()
 	return nil
 }
 
 func NewInputStream(inner drivertypes.InputStream) InputStream {
 	return InputStream{
-		inner: inner,
+		inner:    inner,
+		deadline: new(pollDeadline),
+	}
+}
+
+// NewInputStreamContext 创建可取消的 InputStream，等待数据时 ctx 取消会返回 context.Canceled
+func NewInputStreamContext(ctx context.Context, inner drivertypes.InputStream) InputStream {
+	s := NewInputStream(inner)
+	s.ctx = ctx
+	return s
+}
+
+// streamError 将 wasi 的 stream-error 转换为 error，closed 对应 io.EOF
+func streamError(err streams.StreamError) error {
+	if detail := err.LastOperationFailed(); detail != nil {
+		defer detail.ResourceDrop()
+		return errors.New(detail.ToDebugString())
 	}
+	return io.EOF
 }
 
 type UploadRequest struct {
 	drivertypes.UploadRequest
+
+	// 为 nil 时返回的流阻塞读取
+	ctx context.Context
+}
+
+// NewUploadRequest 创建 UploadRequest，Streams、Peek、NextChunk 返回的流会响应 ctx 的取消
+func NewUploadRequest(ctx context.Context, req drivertypes.UploadRequest) UploadRequest {
+	return UploadRequest{UploadRequest: req, ctx: ctx}
 }
 
 func (us *UploadRequest) GetHash(hashs []drivertypes.HashAlg) ([]drivertypes.HashInfo, error) {
@@ -142,7 +208,7 @@ func (us *UploadRequest) Streams() (io.ReadCloser, error) {
 	if iserr {
 		return nil, errors.New(err)
 	}
-	is := NewInputStream(stream)
+	is := NewInputStreamContext(us.ctx, stream)
 	return &is, nil
 }
 
@@ -151,7 +217,7 @@ func (us *UploadRequest) Peek(offset uint64, length uint64) (io.ReadCloser, erro
 	if iserr {
 		return nil, errors.New(err)
 	}
-	is := NewInputStream(stream)
+	is := NewInputStreamContext(us.ctx, stream)
 	return &is, nil
 }
 
@@ -168,15 +234,20 @@ func (us *UploadRequest) NextChunk() (io.ReadCloser, error) {
 	if iserr {
 		return nil, errors.New(err)
 	}
-	is := NewInputStream(stream)
+	is := NewInputStreamContext(us.ctx, stream)
 	return &is, nil
 }
 
 func (us *UploadRequest) ChunkReset(chunk io.ReadCloser) error {
-	is, ok := chunk.(*InputStream)
+	is, ok := rollbackChunk(chunk).(*InputStream)
 	if !ok {
 		return errors.New("invalid chunk type")
 	}
+	// chunk-reset 会消耗流，子资源 pollable 必须先释放
+	if is.pollable != 0 {
+		is.pollable.ResourceDrop()
+		is.pollable = 0
+	}
 	_, err, iserr := us.Content.ChunkReset(is.inner).Result()
 	if iserr {
 		return errors.New(err)
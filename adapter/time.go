@@ -0,0 +1,41 @@
+package adapter
+
+import (
+	"math"
+	"time"
+
+	drivertypes "github.com/OpenListTeam/openlist-wasi-plugin-driver/binding/openlist/plugin-driver/types"
+	wallclock "github.com/OpenListTeam/openlist-wasi-plugin-driver/binding/wasi/clocks/wall-clock"
+)
+
+// Now 通过 wasi:clocks/wall-clock 获取当前时间
+// world 已导入 wall-clock，time.Now() 同样可用，该函数用于不经过 Go 运行时直接读取宿主时间
+func Now() time.Time {
+	dt := wallclock.Now()
+	return time.Unix(int64(dt.Seconds), int64(dt.Nanoseconds))
+}
+
+// TimeToDuration 将 time.Time 转换为 Object.Created、Object.Modified 等字段使用的时间戳，
+// 即自 1970-01-01T00:00:00Z 起的纳秒数（u64）
+// 零值及 1970 年之前的时间转换为 0，超过 u64 上限（约 2554 年）的时间转换为 math.MaxUint64
+func TimeToDuration(t time.Time) drivertypes.Duration {
+	sec := t.Unix()
+	if t.IsZero() || sec < 0 {
+		return 0
+	}
+	// 不使用 UnixNano，其结果在 2262 年之后溢出
+	const maxSec = math.MaxUint64 / uint64(time.Second)
+	nsec := uint64(t.Nanosecond())
+	if uint64(sec) > maxSec || uint64(sec) == maxSec && nsec > math.MaxUint64%uint64(time.Second) {
+		return math.MaxUint64
+	}
+	return drivertypes.Duration(uint64(sec)*uint64(time.Second) + nsec)
+}
+
+// DurationToTime 将时间戳转换为 time.Time，0 转换为零值，与 TimeToDuration 互逆
+func DurationToTime(d drivertypes.Duration) time.Time {
+	if d == 0 {
+		return time.Time{}
+	}
+	return time.Unix(int64(d/drivertypes.Duration(time.Second)), int64(d%drivertypes.Duration(time.Second)))
+}
@@ -0,0 +1,50 @@
+package adapter
+
+import (
+	"math"
+	"testing"
+	"time"
+
+	drivertypes "github.com/OpenListTeam/openlist-wasi-plugin-driver/binding/openlist/plugin-driver/types"
+)
+
+func TestTimeToDurationRoundTrip(t *testing.T) {
+	for _, tm := range []time.Time{
+		time.Unix(0, 1),
+		time.Date(2024, 2, 29, 12, 30, 45, 123456789, time.UTC),
+		// int64 纳秒的上限，之后 UnixNano 溢出
+		time.Unix(0, math.MaxInt64),
+		time.Unix(0, math.MaxInt64).Add(time.Nanosecond),
+		time.Date(2500, 1, 1, 0, 0, 0, 1, time.UTC),
+		// u64 纳秒的上限
+		time.Unix(int64(math.MaxUint64/uint64(time.Second)), int64(math.MaxUint64%uint64(time.Second))),
+	} {
+		d := TimeToDuration(tm)
+		if back := DurationToTime(d); !back.Equal(tm) {
+			t.Errorf("%v -> %d -> %v", tm, d, back)
+		}
+	}
+}
+
+func TestTimeToDurationBounds(t *testing.T) {
+	tests := []struct {
+		t    time.Time
+		want drivertypes.Duration
+	}{
+		{time.Time{}, 0},
+		{time.Unix(0, 0), 0},
+		{time.Unix(-1, 0), 0},
+		{time.Date(1969, 12, 31, 23, 59, 59, 999999999, time.UTC), 0},
+		{time.Unix(1, 5), 1000000005},
+		{time.Date(2600, 1, 1, 0, 0, 0, 0, time.UTC), math.MaxUint64},
+		{time.Unix(int64(math.MaxUint64/uint64(time.Second)), int64(math.MaxUint64%uint64(time.Second))+1), math.MaxUint64},
+	}
+	for _, tt := range tests {
+		if got := TimeToDuration(tt.t); got != tt.want {
+			t.Errorf("TimeToDuration(%v) = %d, want %d", tt.t, got, tt.want)
+		}
+	}
+	if !DurationToTime(0).IsZero() {
+		t.Error("DurationToTime(0) is not the zero time")
+	}
+}
@@ -0,0 +1,200 @@
+package adapter
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	monotonicclock "github.com/OpenListTeam/openlist-wasi-plugin-driver/binding/wasi/clocks/monotonic-clock"
+	"github.com/OpenListTeam/openlist-wasi-plugin-driver/binding/wasi/io/poll"
+)
+
+// 基于 wasi:clocks/monotonic-clock 的定时器，由 reactor 等待，不依赖 time.Sleep 和 Go 的定时器
+// 与 WarpCancellable 返回的 context 配合使用时，宿主取消会立即生效
+
+// Sleep 等待 d，ctx 取消或到达截止时间时提前返回 ctx.Err()
+func Sleep(ctx context.Context, d time.Duration) error {
+	if d <= 0 {
+		return ctx.Err()
+	}
+	timer := monotonicclock.SubscribeDuration(monotonicclock.Duration(d))
+	defer timer.ResourceDrop()
+	return waitPollable(ctx, timer, time.Time{})
+}
+
+// After 等价于 NewTimer(d).C
+func After(d time.Duration) <-chan time.Time {
+	return NewTimer(d).C
+}
+
+// Timer 对应 time.Timer，到期后向 C 发送当前时间
+type Timer struct {
+	C <-chan time.Time
+	c chan time.Time
+
+	mu       sync.Mutex
+	pollable poll.Pollable
+	id       uint64
+	active   bool
+}
+
+// NewTimer 创建在 d 之后到期的 Timer
+func NewTimer(d time.Duration) *Timer {
+	c := make(chan time.Time, 1)
+	t := &Timer{C: c, c: c}
+	t.mu.Lock()
+	t.start(d)
+	t.mu.Unlock()
+	return t
+}
+
+// Stop 停止 Timer，Timer 已停止或到期后的值已被接收时返回 false
+// 与 Go 1.23 起的 time.Timer 一致，Stop 返回后不会再从 C 收到旧的值
+func (t *Timer) Stop() bool {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	return t.stop()
+}
+
+// Reset 使 Timer 在 d 之后重新到期，返回值与 Stop 相同
+// 与 Go 1.23 起的 time.Timer 一致，Reset 会丢弃 C 中尚未接收的值
+func (t *Timer) Reset(d time.Duration) bool {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	active := t.stop()
+	t.start(d)
+	return active
+}
+
+func (t *Timer) start(d time.Duration) {
+	pollable := monotonicclock.SubscribeDuration(monotonicclock.Duration(max(d, 0)))
+	t.pollable, t.active = pollable, true
+	var id uint64
+	id = defaultReactor.register(pollable, func() { t.fire(id) })
+	t.id = id
+}
+
+// stop 停止等待并丢弃 C 中尚未接收的值，到期但未被接收的 Timer 视为仍在等待
+func (t *Timer) stop() bool {
+	select {
+	case <-t.c:
+		return true
+	default:
+	}
+	if !t.active {
+		return false
+	}
+	defaultReactor.unregister(t.id)
+	t.pollable.ResourceDrop()
+	t.active = false
+	return true
+}
+
+// fire 在执行 poll 的 goroutine 中调用，id 用于忽略 Reset 之前的旧定时器
+func (t *Timer) fire(id uint64) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	if !t.active || t.id != id {
+		return
+	}
+	t.pollable.ResourceDrop()
+	t.active = false
+	select {
+	case t.c <- time.Now():
+	default:
+	}
+}
+
+// Ticker 对应 time.Ticker，每隔 d 向 C 发送当前时间
+// 基于 monotonic-clock 的绝对时刻调度，不会累积误差；接收方处理不及时会丢弃 tick
+type Ticker struct {
+	C <-chan time.Time
+	c chan time.Time
+
+	mu       sync.Mutex
+	period   monotonicclock.Duration
+	next     monotonicclock.Instant
+	pollable poll.Pollable
+	id       uint64
+	active   bool
+}
+
+// NewTicker 创建周期为 d 的 Ticker，d 必须大于 0
+func NewTicker(d time.Duration) *Ticker {
+	if d <= 0 {
+		panic("adapter: non-positive interval for NewTicker")
+	}
+	c := make(chan time.Time, 1)
+	t := &Ticker{C: c, c: c}
+	t.mu.Lock()
+	t.start(d)
+	t.mu.Unlock()
+	return t
+}
+
+// Stop 停止 Ticker，不会关闭 C，返回后不会再从 C 收到旧的 tick
+func (t *Ticker) Stop() {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	t.stop()
+}
+
+// Reset 停止 Ticker 并将周期改为 d，下一次 tick 在 d 之后
+func (t *Ticker) Reset(d time.Duration) {
+	if d <= 0 {
+		panic("adapter: non-positive interval for Ticker.Reset")
+	}
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	t.stop()
+	t.start(d)
+}
+
+func (t *Ticker) start(d time.Duration) {
+	t.period = monotonicclock.Duration(d)
+	t.next = monotonicclock.Now()
+	t.active = true
+	t.schedule()
+}
+
+func (t *Ticker) schedule() {
+	t.next += monotonicclock.Instant(t.period)
+	// 跳过已经错过的 tick
+	if now := monotonicclock.Now(); t.next <= now {
+		t.next += (now - t.next) / monotonicclock.Instant(t.period) * monotonicclock.Instant(t.period)
+		t.next += monotonicclock.Instant(t.period)
+	}
+	pollable := monotonicclock.SubscribeInstant(t.next)
+	t.pollable = pollable
+	var id uint64
+	id = defaultReactor.register(pollable, func() { t.tick(id) })
+	t.id = id
+}
+
+func (t *Ticker) stop() {
+	select {
+	case <-t.c:
+	default:
+	}
+	if !t.active {
+		return
+	}
+	defaultReactor.unregister(t.id)
+	t.pollable.ResourceDrop()
+	t.active = false
+}
+
+// tick 在执行 poll 的 goroutine 中调用，id 用于忽略 Reset 之前的旧定时器
+func (t *Ticker) tick(id uint64) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	if !t.active || t.id != id {
+		return
+	}
+	t.pollable.ResourceDrop()
+	select {
+	case t.c <- time.Now():
+	default:
+	}
+	t.schedule()
+}
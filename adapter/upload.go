@@ -0,0 +1,268 @@
+package adapter
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"math"
+	"sync"
+
+	drivertypes "github.com/OpenListTeam/openlist-wasi-plugin-driver/binding/openlist/plugin-driver/types"
+)
+
+// DefaultChunkSize 是 UploadEngine 未设置 ChunkSize 时使用的分块大小
+const DefaultChunkSize = 5 << 20
+
+// UploadChunk 描述一个待上传的分块
+type UploadChunk struct {
+	// 分块序号，从 1 开始
+	Number uint32 `json:"number"`
+	Offset int64  `json:"offset"`
+	Size   int64  `json:"size"`
+}
+
+// UploadPart 是一个已完成上传的分块，用于最终的合并请求，也是 ResumableUploader 持久化的分块状态
+type UploadPart struct {
+	UploadChunk
+	// 上传回调返回的分块标识，例如 S3 的 ETag
+	ETag string `json:"etag"`
+	// 按 UploadEngine.HashAlgs 计算的分块哈希，不会持久化，从会话恢复的分块为空
+	Hashes []drivertypes.HashInfo `json:"-"`
+}
+
+// CompletedPart 转换为 complete-upload 使用的 CompletedPart
+func (p UploadPart) CompletedPart() drivertypes.CompletedPart {
+	return drivertypes.CompletedPart{PartNumber: p.Number, Etag: p.ETag}
+}
+
+// CompletedParts 将 Upload 的结果转换为 complete-upload 使用的 CompletedPart
+func CompletedParts(parts []UploadPart) []drivertypes.CompletedPart {
+	completed := make([]drivertypes.CompletedPart, len(parts))
+	for i, part := range parts {
+		completed[i] = part.CompletedPart()
+	}
+	return completed
+}
+
+// uploadSource 是 UploadEngine 与 ProgressTracker 使用的 UploadRequest 方法，测试时以内存实现代替宿主
+type uploadSource interface {
+	Streams() (io.ReadCloser, error)
+	Peek(offset uint64, length uint64) (io.ReadCloser, error)
+	Chunks(chunkSize uint32) (uint32, error)
+	NextChunk() (io.ReadCloser, error)
+	ChunkReset(chunk io.ReadCloser) error
+	UpdateProgress(progress float64)
+}
+
+// ChunkUploadFunc 上传单个分块，r 只能读取一次，重试时会传入新的 r
+type ChunkUploadFunc func(ctx context.Context, chunk UploadChunk, r io.Reader) (etag string, err error)
+
+// UploadEngine 基于 UploadRequest 的并发分块上传
+// 分块通过 next-chunk 按顺序获取，由多个 goroutine 并发上传，失败的分块通过 chunk-reset 重新读取
+type UploadEngine struct {
+	// 分块大小，为 0 时使用 DefaultChunkSize
+	ChunkSize uint32
+	// 服务端允许的最大分块数量，超过时自动增大分块大小，为 0 时不限制
+	MaxChunks uint32
+	// 同时上传的分块数量，小于 1 时按 1 处理
+	Concurrency int
+	// 单个分块失败后的重试次数
+	Retries int
+	// 需要为每个分块计算的哈希
+	HashAlgs []drivertypes.HashAlg
+}
+
+// PartSize 根据文件大小计算实际使用的分块大小
+func (e *UploadEngine) PartSize(total int64) uint32 {
+	size := int64(e.ChunkSize)
+	if size <= 0 {
+		size = DefaultChunkSize
+	}
+	if e.MaxChunks > 0 && total > size*int64(e.MaxChunks) {
+		size = (total + int64(e.MaxChunks) - 1) / int64(e.MaxChunks)
+	}
+	return uint32(min(size, math.MaxUint32))
+}
+
+// Upload 上传 req 的全部内容，返回按分块序号排列的结果
+func (e *UploadEngine) Upload(ctx context.Context, req *UploadRequest, upload ChunkUploadFunc) ([]UploadPart, error) {
+	return e.upload(ctx, req, req.Object.Size, upload, nil, nil)
+}
+
+// upload 同 Upload，total 为内容总大小，skip 返回 true 的分块不再上传而直接使用返回的结果，
+// onPart 在每个分块上传完成后调用，返回错误时中止上传；两者都可以为 nil
+func (e *UploadEngine) upload(ctx context.Context, src uploadSource, total int64, upload ChunkUploadFunc, skip func(UploadChunk) (UploadPart, bool), onPart func(UploadPart) error) ([]UploadPart, error) {
+	chunkSize := e.PartSize(total)
+	count, err := src.Chunks(chunkSize)
+	if err != nil {
+		return nil, err
+	}
+	if count == 0 {
+		return nil, nil
+	}
+
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	u := &engineRun{
+		engine:   e,
+		src:      src,
+		upload:   upload,
+		onPart:   onPart,
+		progress: newProgressTracker(src, total),
+		results:  make([]UploadPart, count),
+	}
+
+	type job struct {
+		chunk  UploadChunk
+		stream io.ReadCloser
+	}
+	jobs := make(chan job)
+
+	var wg sync.WaitGroup
+	for range max(e.Concurrency, 1) {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for j := range jobs {
+				if err := u.uploadChunk(ctx, j.chunk, j.stream); err != nil {
+					u.fail(err)
+					cancel()
+				}
+			}
+		}()
+	}
+
+	for number := uint32(1); number <= count; number++ {
+		offset := int64(number-1) * int64(chunkSize)
+		chunk := UploadChunk{
+			Number: number,
+			Offset: offset,
+			Size:   min(int64(chunkSize), total-offset),
+		}
+
+		if skip != nil {
+			if part, ok := skip(chunk); ok {
+				// 跳过的分块同样需要通过 next-chunk 读过
+				if err := u.skip(chunk, part); err != nil {
+					u.fail(err)
+					break
+				}
+				continue
+			}
+		}
+
+		u.mu.Lock()
+		stream, err := u.progress.NextChunk()
+		u.mu.Unlock()
+		if err != nil {
+			u.fail(err)
+			break
+		}
+
+		select {
+		case jobs <- job{chunk: chunk, stream: stream}:
+			continue
+		case <-ctx.Done():
+			stream.Close()
+		}
+		break
+	}
+	close(jobs)
+	wg.Wait()
+
+	if u.err != nil {
+		return nil, u.err
+	}
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+	u.progress.Done()
+	return u.results, nil
+}
+
+// engineRun 保存单次 Upload 的状态
+type engineRun struct {
+	engine *UploadEngine
+	src    uploadSource
+	upload ChunkUploadFunc
+	onPart func(UploadPart) error
+	// 读取分块时自动上报进度，chunk-reset 时回退
+	progress *ProgressTracker
+
+	// 保护 next-chunk/chunk-reset 的调用顺序以及以下字段
+	mu      sync.Mutex
+	err     error
+	results []UploadPart
+}
+
+func (u *engineRun) fail(err error) {
+	u.mu.Lock()
+	defer u.mu.Unlock()
+	if u.err == nil {
+		u.err = err
+	}
+}
+
+func (u *engineRun) uploadChunk(ctx context.Context, chunk UploadChunk, stream io.ReadCloser) error {
+	for attempt := 0; ; attempt++ {
+		if err := ctx.Err(); err != nil {
+			stream.Close()
+			return err
+		}
+
+		hasher, err := NewMultiHasher(u.engine.HashAlgs, chunk.Size)
+		if err != nil {
+			stream.Close()
+			return err
+		}
+		etag, err := u.upload(ctx, chunk, hasher.TeeReader(stream))
+		if err == nil {
+			stream.Close()
+			return u.done(UploadPart{UploadChunk: chunk, ETag: etag, Hashes: hasher.Sum()})
+		}
+		if attempt >= u.engine.Retries || ctx.Err() != nil {
+			stream.Close()
+			return fmt.Errorf("upload chunk %d: %w", chunk.Number, err)
+		}
+
+		// chunk-reset 会消耗该流，随后的 next-chunk 会再次返回同一分块
+		// 两次调用必须在同一把锁内完成，避免被其他分块的 next-chunk 插入
+		u.mu.Lock()
+		if rerr := u.src.ChunkReset(stream); rerr != nil {
+			u.mu.Unlock()
+			return errors.Join(err, rerr)
+		}
+		stream, err = u.progress.NextChunk()
+		u.mu.Unlock()
+		if err != nil {
+			return err
+		}
+	}
+}
+
+func (u *engineRun) done(part UploadPart) error {
+	u.mu.Lock()
+	u.results[part.Number-1] = part
+	u.mu.Unlock()
+	if u.onPart != nil {
+		return u.onPart(part)
+	}
+	return nil
+}
+
+// skip 读过已完成的分块并记录其结果，进度按整个分块计入
+func (u *engineRun) skip(chunk UploadChunk, part UploadPart) error {
+	u.mu.Lock()
+	defer u.mu.Unlock()
+	stream, err := u.src.NextChunk()
+	if err != nil {
+		return err
+	}
+	stream.Close()
+	part.UploadChunk = chunk
+	u.results[chunk.Number-1] = part
+	u.progress.add(chunk.Size)
+	return nil
+}
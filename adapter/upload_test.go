@@ -0,0 +1,400 @@
+package adapter
+
+import (
+	"bytes"
+	"context"
+	"crypto/md5"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"math"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	drivertypes "github.com/OpenListTeam/openlist-wasi-plugin-driver/binding/openlist/plugin-driver/types"
+)
+
+func TestUploadSessionJSON(t *testing.T) {
+	// 旧版本保存的会话没有 offset
+	old := `{"chunk_size":5242880,"extra":{"upload_id":"abc"},"parts":[{"number":2,"size":100,"etag":"\"e2\""}]}`
+	var session UploadSession
+	if err := json.Unmarshal([]byte(old), &session); err != nil {
+		t.Fatal(err)
+	}
+	if len(session.Parts) != 1 {
+		t.Fatalf("parts = %+v", session.Parts)
+	}
+	part := session.Parts[0]
+	if part.Number != 2 || part.Size != 100 || part.ETag != `"e2"` {
+		t.Errorf("part = %+v", part)
+	}
+
+	part.Offset = 5242880
+	data, err := json.Marshal(UploadSession{ChunkSize: 5242880, Parts: []UploadPart{part}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := `{"chunk_size":5242880,"parts":[{"number":2,"offset":5242880,"size":100,"etag":"\"e2\""}]}`
+	if string(data) != want {
+		t.Errorf("json = %s, want %s", data, want)
+	}
+}
+
+func TestCompletedParts(t *testing.T) {
+	parts := []UploadPart{
+		{UploadChunk: UploadChunk{Number: 1, Size: 10}, ETag: "a"},
+		{UploadChunk: UploadChunk{Number: 2, Offset: 10, Size: 5}, ETag: "b"},
+	}
+	completed := CompletedParts(parts)
+	if len(completed) != 2 {
+		t.Fatalf("got %d parts", len(completed))
+	}
+	for i, c := range completed {
+		if c.PartNumber != parts[i].Number || c.Etag != parts[i].ETag {
+			t.Errorf("part %d = %+v", i, c)
+		}
+	}
+}
+
+// fakeSource 在内存中模拟宿主的 next-chunk/chunk-reset，并按顺序记录调用
+type fakeSource struct {
+	data []byte
+
+	mu        sync.Mutex
+	chunkSize int
+	next      uint32 // 下一个新分块的序号
+	reset     uint32 // chunk-reset 后 next-chunk 再次返回的分块，0 表示没有
+	open      int    // 尚未关闭或重置的流
+	log       []string
+	progress  []float64
+}
+
+func newFakeSource(n int) *fakeSource {
+	return &fakeSource{data: hashInput(n), next: 1}
+}
+
+type fakeChunk struct {
+	*bytes.Reader
+	src    *fakeSource
+	number uint32
+	done   bool
+}
+
+func (c *fakeChunk) Close() error {
+	c.src.mu.Lock()
+	defer c.src.mu.Unlock()
+	if !c.done {
+		c.done = true
+		c.src.open--
+	}
+	return nil
+}
+
+func (s *fakeSource) Streams() (io.ReadCloser, error) {
+	return nil, errors.New("not supported")
+}
+
+func (s *fakeSource) Peek(offset uint64, length uint64) (io.ReadCloser, error) {
+	return nil, errors.New("not supported")
+}
+
+func (s *fakeSource) Chunks(chunkSize uint32) (uint32, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.chunkSize = int(chunkSize)
+	return uint32((len(s.data) + s.chunkSize - 1) / s.chunkSize), nil
+}
+
+func (s *fakeSource) NextChunk() (io.ReadCloser, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	number := s.reset
+	if number == 0 {
+		if int(s.next-1)*s.chunkSize >= len(s.data) {
+			return nil, errors.New("no more chunks")
+		}
+		number = s.next
+		s.next++
+	}
+	s.reset = 0
+	s.open++
+	s.log = append(s.log, fmt.Sprintf("next %d", number))
+	off := int(number-1) * s.chunkSize
+	end := min(off+s.chunkSize, len(s.data))
+	return &fakeChunk{Reader: bytes.NewReader(s.data[off:end]), src: s, number: number}, nil
+}
+
+func (s *fakeSource) ChunkReset(chunk io.ReadCloser) error {
+	c, ok := rollbackChunk(chunk).(*fakeChunk)
+	if !ok {
+		return errors.New("invalid chunk type")
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if c.done || s.reset != 0 {
+		return errors.New("invalid chunk-reset")
+	}
+	c.done = true
+	s.open--
+	s.reset = c.number
+	s.log = append(s.log, fmt.Sprintf("reset %d", c.number))
+	return nil
+}
+
+func (s *fakeSource) UpdateProgress(progress float64) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.progress = append(s.progress, progress)
+}
+
+func (s *fakeSource) check(t *testing.T, want ...string) {
+	t.Helper()
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.open != 0 {
+		t.Errorf("%d chunk streams left open", s.open)
+	}
+	if want != nil && strings.Join(s.log, ", ") != strings.Join(want, ", ") {
+		t.Errorf("calls = %v, want %v", s.log, want)
+	}
+}
+
+// readChunk 读取整个分块并以内容作为 etag
+func readChunk(ctx context.Context, chunk UploadChunk, r io.Reader) (string, error) {
+	data, err := io.ReadAll(r)
+	return string(data), err
+}
+
+func TestUploadEngineResults(t *testing.T) {
+	src := newFakeSource(1000)
+	e := UploadEngine{ChunkSize: 64, Concurrency: 4, HashAlgs: []drivertypes.HashAlg{drivertypes.HashAlgMd5()}}
+	parts, err := e.upload(context.Background(), src, 1000, func(ctx context.Context, chunk UploadChunk, r io.Reader) (string, error) {
+		// 让后面的分块先完成
+		time.Sleep(time.Duration(16-chunk.Number) * time.Millisecond)
+		return readChunk(ctx, chunk, r)
+	}, nil, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(parts) != 16 {
+		t.Fatalf("got %d parts, want 16", len(parts))
+	}
+	for i, part := range parts {
+		off := i * 64
+		end := min(off+64, 1000)
+		if part.Number != uint32(i+1) || part.Offset != int64(off) || part.Size != int64(end-off) {
+			t.Errorf("part %d = %+v", i, part.UploadChunk)
+		}
+		if part.ETag != string(src.data[off:end]) {
+			t.Errorf("part %d uploaded the wrong content", part.Number)
+		}
+		if len(part.Hashes) != 1 || part.Hashes[0].Val != fmt.Sprintf("%x", md5.Sum(src.data[off:end])) {
+			t.Errorf("part %d hashes = %v", part.Number, part.Hashes)
+		}
+	}
+	src.check(t)
+	if p := src.progress; len(p) == 0 || p[len(p)-1] != 100 {
+		t.Errorf("progress = %v, want to end at 100", p)
+	}
+}
+
+func TestUploadEngineEmpty(t *testing.T) {
+	src := newFakeSource(0)
+	e := UploadEngine{}
+	parts, err := e.upload(context.Background(), src, 0, func(context.Context, UploadChunk, io.Reader) (string, error) {
+		t.Error("upload called for empty content")
+		return "", nil
+	}, nil, nil)
+	if err != nil || parts != nil {
+		t.Errorf("upload = %v, %v", parts, err)
+	}
+	src.check(t)
+}
+
+func TestUploadEngineConcurrency(t *testing.T) {
+	for _, concurrency := range []int{0, 1, 3} {
+		src := newFakeSource(640)
+		var (
+			mu            sync.Mutex
+			running, peak int
+		)
+		e := UploadEngine{ChunkSize: 64, Concurrency: concurrency}
+		_, err := e.upload(context.Background(), src, 640, func(ctx context.Context, chunk UploadChunk, r io.Reader) (string, error) {
+			mu.Lock()
+			running++
+			peak = max(peak, running)
+			mu.Unlock()
+			time.Sleep(5 * time.Millisecond)
+			mu.Lock()
+			running--
+			mu.Unlock()
+			return readChunk(ctx, chunk, r)
+		}, nil, nil)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if want := max(concurrency, 1); peak != want {
+			t.Errorf("Concurrency %d: peak = %d, want %d", concurrency, peak, want)
+		}
+		src.check(t)
+	}
+}
+
+func TestUploadEngineRetry(t *testing.T) {
+	src := newFakeSource(200)
+	attempts := 0
+	e := UploadEngine{ChunkSize: 100, Retries: 2}
+	parts, err := e.upload(context.Background(), src, 200, func(ctx context.Context, chunk UploadChunk, r io.Reader) (string, error) {
+		if chunk.Number == 2 && attempts < 2 {
+			attempts++
+			// 读取一部分后失败，重试时必须从分块开头读取
+			io.ReadFull(r, make([]byte, 10))
+			return "", errors.New("temporary")
+		}
+		return readChunk(ctx, chunk, r)
+	}, nil, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if parts[1].ETag != string(src.data[100:]) {
+		t.Errorf("retried part content = %q", parts[1].ETag)
+	}
+	src.check(t, "next 1", "next 2", "reset 2", "next 2", "reset 2", "next 2")
+}
+
+func TestUploadEngineRetriesExhausted(t *testing.T) {
+	src := newFakeSource(200)
+	calls := 0
+	e := UploadEngine{ChunkSize: 100, Retries: 1}
+	_, err := e.upload(context.Background(), src, 200, func(ctx context.Context, chunk UploadChunk, r io.Reader) (string, error) {
+		if chunk.Number == 1 {
+			calls++
+			return "", errors.New("permanent")
+		}
+		return readChunk(ctx, chunk, r)
+	}, nil, nil)
+	if err == nil || !strings.Contains(err.Error(), "upload chunk 1: permanent") {
+		t.Errorf("error = %v", err)
+	}
+	if calls != 2 {
+		t.Errorf("chunk 1 uploaded %d times, want 2", calls)
+	}
+	src.check(t)
+}
+
+func TestUploadEngineSkip(t *testing.T) {
+	src := newFakeSource(400)
+	var (
+		mu       sync.Mutex
+		uploaded []uint32
+		reported []uint32
+	)
+	e := UploadEngine{ChunkSize: 100, Concurrency: 2}
+	skip := func(chunk UploadChunk) (UploadPart, bool) {
+		if chunk.Number%2 == 1 {
+			// 会话中保存的分块信息以引擎计算的为准
+			return UploadPart{ETag: fmt.Sprintf("saved %d", chunk.Number)}, true
+		}
+		return UploadPart{}, false
+	}
+	onPart := func(part UploadPart) error {
+		mu.Lock()
+		defer mu.Unlock()
+		reported = append(reported, part.Number)
+		return nil
+	}
+	parts, err := e.upload(context.Background(), src, 400, func(ctx context.Context, chunk UploadChunk, r io.Reader) (string, error) {
+		mu.Lock()
+		uploaded = append(uploaded, chunk.Number)
+		mu.Unlock()
+		return readChunk(ctx, chunk, r)
+	}, skip, onPart)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for i, part := range parts {
+		if part.Number != uint32(i+1) || part.Offset != int64(i*100) || part.Size != 100 {
+			t.Errorf("part %d = %+v", i, part.UploadChunk)
+		}
+		want := string(src.data[i*100 : i*100+100])
+		if part.Number%2 == 1 {
+			want = fmt.Sprintf("saved %d", part.Number)
+		}
+		if part.ETag != want {
+			t.Errorf("part %d etag = %q", part.Number, part.ETag)
+		}
+	}
+	if len(uploaded) != 2 || len(reported) != 2 {
+		t.Errorf("uploaded %v, reported %v, want chunks 2 and 4", uploaded, reported)
+	}
+	// 跳过的分块同样按顺序读过
+	src.check(t, "next 1", "next 2", "next 3", "next 4")
+}
+
+func TestUploadEngineOnPartError(t *testing.T) {
+	src := newFakeSource(400)
+	errSave := errors.New("save failed")
+	e := UploadEngine{ChunkSize: 100}
+	_, err := e.upload(context.Background(), src, 400, readChunk, nil, func(part UploadPart) error {
+		if part.Number == 2 {
+			return errSave
+		}
+		return nil
+	})
+	if !errors.Is(err, errSave) {
+		t.Errorf("error = %v, want %v", err, errSave)
+	}
+	src.check(t)
+}
+
+func TestProgressTrackerRollback(t *testing.T) {
+	src := newFakeSource(100)
+	src.Chunks(100)
+	tracker := newProgressTracker(src, 100)
+	tracker.Interval = time.Nanosecond
+
+	chunk, err := tracker.NextChunk()
+	if err != nil {
+		t.Fatal(err)
+	}
+	io.ReadFull(chunk, make([]byte, 50))
+	if err := src.ChunkReset(chunk); err != nil {
+		t.Fatal(err)
+	}
+	chunk, err = tracker.NextChunk()
+	if err != nil {
+		t.Fatal(err)
+	}
+	io.ReadAll(chunk)
+	chunk.Close()
+
+	want := []float64{50, 0, 100}
+	if fmt.Sprint(src.progress) != fmt.Sprint(want) {
+		t.Errorf("progress = %v, want %v", src.progress, want)
+	}
+	src.check(t, "next 1", "reset 1", "next 1")
+}
+
+func TestPartSize(t *testing.T) {
+	tests := []struct {
+		engine UploadEngine
+		total  int64
+		want   uint32
+	}{
+		{UploadEngine{}, 100, DefaultChunkSize},
+		{UploadEngine{ChunkSize: 10}, 100, 10},
+		{UploadEngine{ChunkSize: 10, MaxChunks: 10}, 100, 10},
+		// 超过最大分块数量时向上取整增大分块
+		{UploadEngine{ChunkSize: 10, MaxChunks: 10}, 101, 11},
+		{UploadEngine{MaxChunks: 10000}, 100 << 30, 10737419},
+		{UploadEngine{MaxChunks: 1}, 1 << 40, math.MaxUint32},
+	}
+	for _, tt := range tests {
+		if got := tt.engine.PartSize(tt.total); got != tt.want {
+			t.Errorf("%+v.PartSize(%d) = %d, want %d", tt.engine, tt.total, got, tt.want)
+		}
+	}
+}
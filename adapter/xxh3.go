@@ -0,0 +1,243 @@
+package adapter
+
+import (
+	"encoding/binary"
+	"hash"
+	"math/bits"
+)
+
+// XXH3 64 位（seed 为 0，默认 secret）的纯 Go 实现
+
+const (
+	xxhPrime32_1 = 0x9E3779B1
+	xxhPrime32_2 = 0x85EBCA77
+	xxhPrime32_3 = 0xC2B2AE3D
+	xxhPrime64_1 = 0x9E3779B185EBCA87
+	xxhPrime64_2 = 0xC2B2AE3D27D4EB4F
+	xxhPrime64_3 = 0x165667B19E3779F9
+	xxhPrime64_4 = 0x85EBCA77C2B2AE63
+	xxhPrime64_5 = 0x27D4EB2F165667C5
+	xxhPrimeMx1  = 0x165667919E3779F9
+	xxhPrimeMx2  = 0x9FB21C651E98DF25
+
+	xxh3StripeLen     = 64
+	xxh3SecretSize    = 192
+	xxh3StripesPerBlk = (xxh3SecretSize - xxh3StripeLen) / 8
+	xxh3BlockLen      = xxh3StripeLen * xxh3StripesPerBlk
+	xxh3MidSizeMax    = 240
+)
+
+var xxh3Secret = [xxh3SecretSize]byte{
+	0xb8, 0xfe, 0x6c, 0x39, 0x23, 0xa4, 0x4b, 0xbe, 0x7c, 0x01, 0x81, 0x2c, 0xf7, 0x21, 0xad, 0x1c,
+	0xde, 0xd4, 0x6d, 0xe9, 0x83, 0x90, 0x97, 0xdb, 0x72, 0x40, 0xa4, 0xa4, 0xb7, 0xb3, 0x67, 0x1f,
+	0xcb, 0x79, 0xe6, 0x4e, 0xcc, 0xc0, 0xe5, 0x78, 0x82, 0x5a, 0xd0, 0x7d, 0xcc, 0xff, 0x72, 0x21,
+	0xb8, 0x08, 0x46, 0x74, 0xf7, 0x43, 0x24, 0x8e, 0xe0, 0x35, 0x90, 0xe6, 0x81, 0x3a, 0x26, 0x4c,
+	0x3c, 0x28, 0x52, 0xbb, 0x91, 0xc3, 0x00, 0xcb, 0x88, 0xd0, 0x65, 0x8b, 0x1b, 0x53, 0x2e, 0xa3,
+	0x71, 0x64, 0x48, 0x97, 0xa2, 0x0d, 0xf9, 0x4e, 0x38, 0x19, 0xef, 0x46, 0xa9, 0xde, 0xac, 0xd8,
+	0xa8, 0xfa, 0x76, 0x3f, 0xe3, 0x9c, 0x34, 0x3f, 0xf9, 0xdc, 0xbb, 0xc7, 0xc7, 0x0b, 0x4f, 0x1d,
+	0x8a, 0x51, 0xe0, 0x4b, 0xcd, 0xb4, 0x59, 0x31, 0xc8, 0x9f, 0x7e, 0xc9, 0xd9, 0x78, 0x73, 0x64,
+	0xea, 0xc5, 0xac, 0x83, 0x34, 0xd3, 0xeb, 0xc3, 0xc5, 0x81, 0xa0, 0xff, 0xfa, 0x13, 0x63, 0xeb,
+	0x17, 0x0d, 0xdd, 0x51, 0xb7, 0xf0, 0xda, 0x49, 0xd3, 0x16, 0x55, 0x26, 0x29, 0xd4, 0x68, 0x9e,
+	0x2b, 0x16, 0xbe, 0x58, 0x7d, 0x47, 0xa1, 0xfc, 0x8f, 0xf8, 0xb8, 0xd1, 0x7a, 0xd0, 0x31, 0xce,
+	0x45, 0xcb, 0x3a, 0x8f, 0x95, 0x16, 0x04, 0x28, 0xaf, 0xd7, 0xfb, 0xca, 0xbb, 0x4b, 0x40, 0x7e,
+}
+
+var xxh3InitAcc = [8]uint64{
+	xxhPrime32_3, xxhPrime64_1, xxhPrime64_2, xxhPrime64_3,
+	xxhPrime64_4, xxhPrime32_2, xxhPrime64_5, xxhPrime32_1,
+}
+
+// xxh3 流式计算
+// 只有在确认后面还有数据时才处理缓冲区中的完整块，保证 Sum 时缓冲区非空，
+// 这样已处理的块数与一次性计算时的 (len-1)/blockLen 相同
+type xxh3 struct {
+	acc    [8]uint64
+	buf    []byte
+	prev   [xxh3StripeLen]byte
+	length uint64
+}
+
+// NewXxh3 创建 XXH3 64 位哈希
+func NewXxh3() hash.Hash {
+	x := &xxh3{buf: make([]byte, 0, xxh3BlockLen)}
+	x.Reset()
+	return x
+}
+
+func (x *xxh3) Write(p []byte) (int, error) {
+	n := len(p)
+	x.length += uint64(n)
+	for len(p) > 0 {
+		if len(x.buf) == xxh3BlockLen {
+			xxh3Block(&x.acc, x.buf)
+			copy(x.prev[:], x.buf[xxh3BlockLen-xxh3StripeLen:])
+			x.buf = x.buf[:0]
+		}
+		m := copy(x.buf[len(x.buf):xxh3BlockLen], p)
+		x.buf = x.buf[:len(x.buf)+m]
+		p = p[m:]
+	}
+	return n, nil
+}
+
+func (x *xxh3) Sum(b []byte) []byte {
+	return binary.BigEndian.AppendUint64(b, x.Sum64())
+}
+
+// Sum64 返回 64 位哈希值
+func (x *xxh3) Sum64() uint64 {
+	if x.length <= xxh3MidSizeMax {
+		return xxh3Short(x.buf)
+	}
+
+	acc := x.acc
+	stripes := (len(x.buf) - 1) / xxh3StripeLen
+	xxh3Accumulate(&acc, x.buf, stripes)
+
+	var last [xxh3StripeLen]byte
+	if len(x.buf) >= xxh3StripeLen {
+		copy(last[:], x.buf[len(x.buf)-xxh3StripeLen:])
+	} else {
+		n := copy(last[:], x.prev[len(x.buf):])
+		copy(last[n:], x.buf)
+	}
+	xxh3Accumulate512(&acc, last[:], xxh3Secret[xxh3SecretSize-xxh3StripeLen-7:])
+	return xxh3MergeAccs(&acc, xxh3Secret[11:], x.length*xxhPrime64_1)
+}
+
+func (x *xxh3) Reset() {
+	x.acc = xxh3InitAcc
+	x.buf = x.buf[:0]
+	x.prev = [xxh3StripeLen]byte{}
+	x.length = 0
+}
+
+func (x *xxh3) Size() int      { return 8 }
+func (x *xxh3) BlockSize() int { return xxh3StripeLen }
+
+func xxh3Block(acc *[8]uint64, block []byte) {
+	xxh3Accumulate(acc, block, xxh3StripesPerBlk)
+	xxh3Scramble(acc, xxh3Secret[xxh3SecretSize-xxh3StripeLen:])
+}
+
+func xxh3Accumulate(acc *[8]uint64, data []byte, stripes int) {
+	for s := range stripes {
+		xxh3Accumulate512(acc, data[s*xxh3StripeLen:], xxh3Secret[s*8:])
+	}
+}
+
+func xxh3Accumulate512(acc *[8]uint64, data []byte, secret []byte) {
+	for i := range 8 {
+		v := binary.LittleEndian.Uint64(data[8*i:])
+		k := v ^ binary.LittleEndian.Uint64(secret[8*i:])
+		acc[i^1] += v
+		acc[i] += uint64(uint32(k)) * (k >> 32)
+	}
+}
+
+func xxh3Scramble(acc *[8]uint64, secret []byte) {
+	for i := range 8 {
+		a := acc[i]
+		a ^= a >> 47
+		a ^= binary.LittleEndian.Uint64(secret[8*i:])
+		acc[i] = a * xxhPrime32_1
+	}
+}
+
+func xxh3MergeAccs(acc *[8]uint64, secret []byte, start uint64) uint64 {
+	result := start
+	for i := range 4 {
+		result += xxh3Mul128Fold64(
+			acc[2*i]^binary.LittleEndian.Uint64(secret[16*i:]),
+			acc[2*i+1]^binary.LittleEndian.Uint64(secret[16*i+8:]),
+		)
+	}
+	return xxh3Avalanche(result)
+}
+
+func xxh3Short(p []byte) uint64 {
+	n := uint64(len(p))
+	s := xxh3Secret[:]
+	switch {
+	case n == 0:
+		return xxh64Avalanche(binary.LittleEndian.Uint64(s[56:]) ^ binary.LittleEndian.Uint64(s[64:]))
+	case n <= 3:
+		combined := uint32(p[0])<<16 | uint32(p[n>>1])<<24 | uint32(p[n-1]) | uint32(n)<<8
+		flip := uint64(binary.LittleEndian.Uint32(s) ^ binary.LittleEndian.Uint32(s[4:]))
+		return xxh64Avalanche(uint64(combined) ^ flip)
+	case n <= 8:
+		in1 := binary.LittleEndian.Uint32(p)
+		in2 := binary.LittleEndian.Uint32(p[n-4:])
+		flip := binary.LittleEndian.Uint64(s[8:]) ^ binary.LittleEndian.Uint64(s[16:])
+		return xxh3Rrmxmx((uint64(in2)+uint64(in1)<<32)^flip, n)
+	case n <= 16:
+		flip1 := binary.LittleEndian.Uint64(s[24:]) ^ binary.LittleEndian.Uint64(s[32:])
+		flip2 := binary.LittleEndian.Uint64(s[40:]) ^ binary.LittleEndian.Uint64(s[48:])
+		lo := binary.LittleEndian.Uint64(p) ^ flip1
+		hi := binary.LittleEndian.Uint64(p[n-8:]) ^ flip2
+		return xxh3Avalanche(n + bits.ReverseBytes64(lo) + hi + xxh3Mul128Fold64(lo, hi))
+	case n <= 128:
+		acc := n * xxhPrime64_1
+		if n > 32 {
+			if n > 64 {
+				if n > 96 {
+					acc += xxh3Mix16(p[48:], s[96:])
+					acc += xxh3Mix16(p[n-64:], s[112:])
+				}
+				acc += xxh3Mix16(p[32:], s[64:])
+				acc += xxh3Mix16(p[n-48:], s[80:])
+			}
+			acc += xxh3Mix16(p[16:], s[32:])
+			acc += xxh3Mix16(p[n-32:], s[48:])
+		}
+		acc += xxh3Mix16(p, s)
+		acc += xxh3Mix16(p[n-16:], s[16:])
+		return xxh3Avalanche(acc)
+	default:
+		acc := n * xxhPrime64_1
+		rounds := int(n / 16)
+		for i := range 8 {
+			acc += xxh3Mix16(p[16*i:], s[16*i:])
+		}
+		acc = xxh3Avalanche(acc)
+		for i := 8; i < rounds; i++ {
+			acc += xxh3Mix16(p[16*i:], s[16*(i-8)+3:])
+		}
+		acc += xxh3Mix16(p[n-16:], s[136-17:])
+		return xxh3Avalanche(acc)
+	}
+}
+
+func xxh3Mix16(p []byte, s []byte) uint64 {
+	return xxh3Mul128Fold64(
+		binary.LittleEndian.Uint64(p)^binary.LittleEndian.Uint64(s),
+		binary.LittleEndian.Uint64(p[8:])^binary.LittleEndian.Uint64(s[8:]),
+	)
+}
+
+func xxh3Mul128Fold64(a, b uint64) uint64 {
+	hi, lo := bits.Mul64(a, b)
+	return hi ^ lo
+}
+
+func xxh3Avalanche(h uint64) uint64 {
+	h ^= h >> 37
+	h *= xxhPrimeMx1
+	return h ^ h>>32
+}
+
+func xxh3Rrmxmx(h uint64, n uint64) uint64 {
+	h ^= bits.RotateLeft64(h, 49) ^ bits.RotateLeft64(h, 24)
+	h *= xxhPrimeMx2
+	h ^= (h >> 35) + n
+	h *= xxhPrimeMx2
+	return h ^ h>>28
+}
+
+func xxh64Avalanche(h uint64) uint64 {
+	h ^= h >> 33
+	h *= xxhPrime64_2
+	h ^= h >> 29
+	h *= xxhPrime64_3
+	return h ^ h>>32
+}
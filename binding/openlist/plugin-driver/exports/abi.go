@@ -19,6 +19,12 @@ type DriverErrorsShape struct {
 	shape [unsafe.Sizeof(DriverErrors{})]byte
 }
 
+// ListPageShape is used for storage in variant or result types.
+type ListPageShape struct {
+	_     cm.HostLayout
+	shape [unsafe.Sizeof(ListPage{})]byte
+}
+
 // LinkResultShape is used for storage in variant or result types.
 type LinkResultShape struct {
 	_     cm.HostLayout
@@ -30,3 +36,33 @@ type OptionObjectShape struct {
 	_     cm.HostLayout
 	shape [unsafe.Sizeof(cm.Option[Object]{})]byte
 }
+
+// StorageDetailsShape is used for storage in variant or result types.
+type StorageDetailsShape struct {
+	_     cm.HostLayout
+	shape [unsafe.Sizeof(StorageDetails{})]byte
+}
+
+// OfflineTaskShape is used for storage in variant or result types.
+type OfflineTaskShape struct {
+	_     cm.HostLayout
+	shape [unsafe.Sizeof(OfflineTask{})]byte
+}
+
+// ArchiveMetaShape is used for storage in variant or result types.
+type ArchiveMetaShape struct {
+	_     cm.HostLayout
+	shape [unsafe.Sizeof(ArchiveMeta{})]byte
+}
+
+// ShareInfoShape is used for storage in variant or result types.
+type ShareInfoShape struct {
+	_     cm.HostLayout
+	shape [unsafe.Sizeof(ShareInfo{})]byte
+}
+
+// UploadLinkInfoShape is used for storage in variant or result types.
+type UploadLinkInfoShape struct {
+	_     cm.HostLayout
+	shape [unsafe.Sizeof(UploadLinkInfo{})]byte
+}
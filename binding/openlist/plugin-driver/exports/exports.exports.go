@@ -55,12 +55,35 @@ var Exports struct {
 	//	get-root: func(ctx: borrow<cancellable>) -> result<object, driver-errors>
 	GetRoot func(ctx cm.Rep) (result cm.Result[ObjectShape, Object, DriverErrors])
 
+	// GetDetails represents the caller-defined, exported function "get-details".
+	//
+	// 获取存储空间的总量与用量。
+	//
+	//	get-details: func(ctx: borrow<cancellable>) -> result<storage-details, driver-errors>
+	GetDetails func(ctx cm.Rep) (result cm.Result[StorageDetailsShape, StorageDetails, DriverErrors])
+
 	// ListFiles represents the caller-defined, exported function "list-files".
 	//
 	//	list-files: func(ctx: borrow<cancellable>, dir: object) -> result<list<object>,
 	//	driver-errors>
 	ListFiles func(ctx cm.Rep, dir Object) (result cm.Result[DriverErrorsShape, cm.List[Object], DriverErrors])
 
+	// ListFilesPage represents the caller-defined, exported function "list-files-page".
+	//
+	// 分页列举目录，cursor 为 none 时从第一页开始，limit 为 0 时由驱动决定页大小。
+	//
+	//	list-files-page: func(ctx: borrow<cancellable>, dir: object, cursor: option<string>,
+	//	limit: u32) -> result<list-page, driver-errors>
+	ListFilesPage func(ctx cm.Rep, dir Object, cursor cm.Option[string], limit uint32) (result cm.Result[ListPageShape, ListPage, DriverErrors])
+
+	// Search represents the caller-defined, exported function "search".
+	//
+	// 在 dir 下搜索，返回的 object 必须带有完整路径，以便宿主建立索引。
+	//
+	//	search: func(ctx: borrow<cancellable>, dir: object, keywords: string, scope: search-scope,
+	//	page: search-page) -> result<list<object>, driver-errors>
+	Search func(ctx cm.Rep, dir Object, keywords string, scope SearchScope, page SearchPage) (result cm.Result[DriverErrorsShape, cm.List[Object], DriverErrors])
+
 	// LinkFile represents the caller-defined, exported function "link-file".
 	//
 	//	link-file: func(ctx: borrow<cancellable>, file: object, args: link-args) -> result<link-result,
@@ -107,4 +130,174 @@ var Exports struct {
 	//	upload-file: func(ctx: borrow<cancellable>, dir: object, req: upload-request) ->
 	//	result<option<object>, driver-errors>
 	UploadFile func(ctx cm.Rep, dir Object, req UploadRequest) (result cm.Result[OptionObjectShape, cm.Option[Object], DriverErrors])
+
+	// UploadURL represents the caller-defined, exported function "upload-url".
+	//
+	// 由网盘服务端从 url 获取内容，在 dir 下创建名为 name 的文件。
+	//
+	//	upload-url: func(ctx: borrow<cancellable>, dir: object, name: string, url: string)
+	//	-> result<option<object>, driver-errors>
+	UploadURL func(ctx cm.Rep, dir Object, name string, url string) (result cm.Result[OptionObjectShape, cm.Option[Object], DriverErrors])
+
+	// UploadLink represents the caller-defined, exported function "upload-link".
+	//
+	// 获取预签名的上传地址，由宿主直接上传 file 描述的内容到 dir 下。
+	//
+	//	upload-link: func(ctx: borrow<cancellable>, dir: object, file: object) -> result<upload-link-info,
+	//	driver-errors>
+	UploadLink func(ctx cm.Rep, dir Object, file Object) (result cm.Result[UploadLinkInfoShape, UploadLinkInfo, DriverErrors])
+
+	// CompleteUpload represents the caller-defined, exported function "complete-upload".
+	//
+	// 宿主上传完所有分块后调用，完成上传。
+	//
+	//	complete-upload: func(ctx: borrow<cancellable>, dir: object, file: object, upload-id:
+	//	string, parts: list<completed-part>) -> result<option<object>, driver-errors>
+	CompleteUpload func(ctx cm.Rep, dir Object, file Object, uploadID string, parts cm.List[CompletedPart]) (result cm.Result[OptionObjectShape, cm.Option[Object], DriverErrors])
+
+	// OfflineDownload represents the caller-defined, exported function "offline-download".
+	//
+	// --- 离线下载 ---
+	// 提交离线下载任务，由网盘服务端将 url 下载到 to-dir。
+	//
+	//	offline-download: func(ctx: borrow<cancellable>, url: string, to-dir: object) ->
+	//	result<offline-task, driver-errors>
+	OfflineDownload func(ctx cm.Rep, url string, toDir Object) (result cm.Result[OfflineTaskShape, OfflineTask, DriverErrors])
+
+	// OfflineTaskStatus represents the caller-defined, exported function "offline-task-status".
+	//
+	// 查询离线下载任务的状态。
+	//
+	//	offline-task-status: func(ctx: borrow<cancellable>, task-id: string) -> result<offline-task,
+	//	driver-errors>
+	OfflineTaskStatus func(ctx cm.Rep, taskID string) (result cm.Result[OfflineTaskShape, OfflineTask, DriverErrors])
+
+	// OfflineTaskCancel represents the caller-defined, exported function "offline-task-cancel".
+	//
+	// 取消离线下载任务。
+	//
+	//	offline-task-cancel: func(ctx: borrow<cancellable>, task-id: string) -> result<_,
+	//	driver-errors>
+	OfflineTaskCancel func(ctx cm.Rep, taskID string) (result cm.Result[DriverErrors, struct{}, DriverErrors])
+
+	// ArchiveMeta represents the caller-defined, exported function "archive-meta".
+	//
+	// --- 压缩包 ---
+	// 获取压缩包的元信息。
+	//
+	//	archive-meta: func(ctx: borrow<cancellable>, file: object, password: string) ->
+	//	result<archive-meta, driver-errors>
+	ArchiveMeta func(ctx cm.Rep, file Object, password string) (result cm.Result[ArchiveMetaShape, ArchiveMeta, DriverErrors])
+
+	// ListArchive represents the caller-defined, exported function "list-archive".
+	//
+	// 列举压缩包内 inner-path 目录下的对象。
+	//
+	//	list-archive: func(ctx: borrow<cancellable>, file: object, args: archive-args) ->
+	//	result<list<object>, driver-errors>
+	ListArchive func(ctx cm.Rep, file Object, args ArchiveArgs) (result cm.Result[DriverErrorsShape, cm.List[Object], DriverErrors])
+
+	// LinkArchiveEntry represents the caller-defined, exported function "link-archive-entry".
+	//
+	// 获取压缩包内 inner-path 文件的链接。
+	//
+	//	link-archive-entry: func(ctx: borrow<cancellable>, file: object, args: archive-args,
+	//	link: link-args) -> result<link-result, driver-errors>
+	LinkArchiveEntry func(ctx cm.Rep, file Object, args ArchiveArgs, link LinkArgs) (result cm.Result[LinkResultShape, LinkResult, DriverErrors])
+
+	// ExtractArchive represents the caller-defined, exported function "extract-archive".
+	//
+	// 在服务端将压缩包内的 inner-path 解压到 to-dir，返回解压得到的对象。
+	//
+	//	extract-archive: func(ctx: borrow<cancellable>, file: object, args: archive-args,
+	//	to-dir: object) -> result<list<object>, driver-errors>
+	ExtractArchive func(ctx cm.Rep, file Object, args ArchiveArgs, toDir Object) (result cm.Result[DriverErrorsShape, cm.List[Object], DriverErrors])
+
+	// ListTrash represents the caller-defined, exported function "list-trash".
+	//
+	// --- 回收站 ---
+	// 列举回收站中的对象。
+	//
+	//	list-trash: func(ctx: borrow<cancellable>) -> result<list<trashed-object>, driver-errors>
+	ListTrash func(ctx cm.Rep) (result cm.Result[DriverErrorsShape, cm.List[TrashedObject], DriverErrors])
+
+	// RestoreFile represents the caller-defined, exported function "restore-file".
+	//
+	// 将回收站中的对象恢复到原始位置。
+	//
+	//	restore-file: func(ctx: borrow<cancellable>, file: object) -> result<option<object>,
+	//	driver-errors>
+	RestoreFile func(ctx cm.Rep, file Object) (result cm.Result[OptionObjectShape, cm.Option[Object], DriverErrors])
+
+	// PurgeFile represents the caller-defined, exported function "purge-file".
+	//
+	// 从回收站中彻底删除对象。
+	//
+	//	purge-file: func(ctx: borrow<cancellable>, file: object) -> result<_, driver-errors>
+	PurgeFile func(ctx cm.Rep, file Object) (result cm.Result[DriverErrors, struct{}, DriverErrors])
+
+	// EmptyTrash represents the caller-defined, exported function "empty-trash".
+	//
+	// 清空回收站。
+	//
+	//	empty-trash: func(ctx: borrow<cancellable>) -> result<_, driver-errors>
+	EmptyTrash func(ctx cm.Rep) (result cm.Result[DriverErrors, struct{}, DriverErrors])
+
+	// ListVersions represents the caller-defined, exported function "list-versions".
+	//
+	// --- 历史版本 ---
+	// 列举文件的历史版本。
+	//
+	//	list-versions: func(ctx: borrow<cancellable>, file: object) -> result<list<file-version>,
+	//	driver-errors>
+	ListVersions func(ctx cm.Rep, file Object) (result cm.Result[DriverErrorsShape, cm.List[FileVersion], DriverErrors])
+
+	// LinkVersion represents the caller-defined, exported function "link-version".
+	//
+	// 获取文件指定版本的链接。
+	//
+	//	link-version: func(ctx: borrow<cancellable>, file: object, version-id: string, args:
+	//	link-args) -> result<link-result, driver-errors>
+	LinkVersion func(ctx cm.Rep, file Object, versionID string, args LinkArgs) (result cm.Result[LinkResultShape, LinkResult, DriverErrors])
+
+	// RestoreVersion represents the caller-defined, exported function "restore-version".
+	//
+	// 将文件回滚到指定版本。
+	//
+	//	restore-version: func(ctx: borrow<cancellable>, file: object, version-id: string)
+	//	-> result<option<object>, driver-errors>
+	RestoreVersion func(ctx cm.Rep, file Object, versionID string) (result cm.Result[OptionObjectShape, cm.Option[Object], DriverErrors])
+
+	// CreateShare represents the caller-defined, exported function "create-share".
+	//
+	// --- 分享 ---
+	// 为对象创建分享链接。
+	//
+	//	create-share: func(ctx: borrow<cancellable>, file: object, options: share-options)
+	//	-> result<share-info, driver-errors>
+	CreateShare func(ctx cm.Rep, file Object, options ShareOptions) (result cm.Result[ShareInfoShape, ShareInfo, DriverErrors])
+
+	// ListShares represents the caller-defined, exported function "list-shares".
+	//
+	// 列举对象的分享链接，file 为 none 时列举全部分享。
+	//
+	//	list-shares: func(ctx: borrow<cancellable>, file: option<object>) -> result<list<share-info>,
+	//	driver-errors>
+	ListShares func(ctx cm.Rep, file cm.Option[Object]) (result cm.Result[DriverErrorsShape, cm.List[ShareInfo], DriverErrors])
+
+	// RevokeShare represents the caller-defined, exported function "revoke-share".
+	//
+	// 撤销分享链接。
+	//
+	//	revoke-share: func(ctx: borrow<cancellable>, share-id: string) -> result<_, driver-errors>
+	RevokeShare func(ctx cm.Rep, shareID string) (result cm.Result[DriverErrors, struct{}, DriverErrors])
+
+	// Other represents the caller-defined, exported function "other".
+	//
+	// --- 自定义操作 ---
+	// 执行驱动特定的操作，参数与返回值均为 JSON。
+	//
+	//	other: func(ctx: borrow<cancellable>, file: object, method: string, json-args: list<u8>)
+	//	-> result<list<u8>, driver-errors>
+	Other func(ctx cm.Rep, file Object, method string, jsonArgs cm.List[uint8]) (result cm.Result[DriverErrorsShape, cm.List[uint8], DriverErrors])
 }
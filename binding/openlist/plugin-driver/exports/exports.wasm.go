@@ -69,6 +69,15 @@ func wasmexport_GetRoot(ctx0 uint32) (result *cm.Result[ObjectShape, Object, Dri
 	return
 }
 
+//go:wasmexport openlist:plugin-driver/exports@0.1.0#get-details
+//export openlist:plugin-driver/exports@0.1.0#get-details
+func wasmexport_GetDetails(ctx0 uint32) (result *cm.Result[StorageDetailsShape, StorageDetails, DriverErrors]) {
+	ctx := cm.Reinterpret[cm.Rep]((uint32)(ctx0))
+	result_ := Exports.GetDetails(ctx)
+	result = &result_
+	return
+}
+
 //go:wasmexport openlist:plugin-driver/exports@0.1.0#list-files
 //export openlist:plugin-driver/exports@0.1.0#list-files
 func wasmexport_ListFiles(params *wasmexport_ListFiles_params) (result *cm.Result[DriverErrorsShape, cm.List[Object], DriverErrors]) {
@@ -77,6 +86,22 @@ func wasmexport_ListFiles(params *wasmexport_ListFiles_params) (result *cm.Resul
 	return
 }
 
+//go:wasmexport openlist:plugin-driver/exports@0.1.0#list-files-page
+//export openlist:plugin-driver/exports@0.1.0#list-files-page
+func wasmexport_ListFilesPage(params *wasmexport_ListFilesPage_params) (result *cm.Result[ListPageShape, ListPage, DriverErrors]) {
+	result_ := Exports.ListFilesPage(params.ctx, params.dir, params.cursor, params.limit)
+	result = &result_
+	return
+}
+
+//go:wasmexport openlist:plugin-driver/exports@0.1.0#search
+//export openlist:plugin-driver/exports@0.1.0#search
+func wasmexport_Search(params *wasmexport_Search_params) (result *cm.Result[DriverErrorsShape, cm.List[Object], DriverErrors]) {
+	result_ := Exports.Search(params.ctx, params.dir, params.keywords, params.scope, params.page)
+	result = &result_
+	return
+}
+
 //go:wasmexport openlist:plugin-driver/exports@0.1.0#link-file
 //export openlist:plugin-driver/exports@0.1.0#link-file
 func wasmexport_LinkFile(params *wasmexport_LinkFile_params) (result *cm.Result[LinkResultShape, LinkResult, DriverErrors]) {
@@ -140,3 +165,179 @@ func wasmexport_UploadFile(params *wasmexport_UploadFile_params) (result *cm.Res
 	result = &result_
 	return
 }
+
+//go:wasmexport openlist:plugin-driver/exports@0.1.0#upload-url
+//export openlist:plugin-driver/exports@0.1.0#upload-url
+func wasmexport_UploadURL(params *wasmexport_UploadURL_params) (result *cm.Result[OptionObjectShape, cm.Option[Object], DriverErrors]) {
+	result_ := Exports.UploadURL(params.ctx, params.dir, params.name, params.url)
+	result = &result_
+	return
+}
+
+//go:wasmexport openlist:plugin-driver/exports@0.1.0#upload-link
+//export openlist:plugin-driver/exports@0.1.0#upload-link
+func wasmexport_UploadLink(params *wasmexport_UploadLink_params) (result *cm.Result[UploadLinkInfoShape, UploadLinkInfo, DriverErrors]) {
+	result_ := Exports.UploadLink(params.ctx, params.dir, params.file)
+	result = &result_
+	return
+}
+
+//go:wasmexport openlist:plugin-driver/exports@0.1.0#complete-upload
+//export openlist:plugin-driver/exports@0.1.0#complete-upload
+func wasmexport_CompleteUpload(params *wasmexport_CompleteUpload_params) (result *cm.Result[OptionObjectShape, cm.Option[Object], DriverErrors]) {
+	result_ := Exports.CompleteUpload(params.ctx, params.dir, params.file, params.uploadID, params.parts)
+	result = &result_
+	return
+}
+
+//go:wasmexport openlist:plugin-driver/exports@0.1.0#offline-download
+//export openlist:plugin-driver/exports@0.1.0#offline-download
+func wasmexport_OfflineDownload(params *wasmexport_OfflineDownload_params) (result *cm.Result[OfflineTaskShape, OfflineTask, DriverErrors]) {
+	result_ := Exports.OfflineDownload(params.ctx, params.url, params.toDir)
+	result = &result_
+	return
+}
+
+//go:wasmexport openlist:plugin-driver/exports@0.1.0#offline-task-status
+//export openlist:plugin-driver/exports@0.1.0#offline-task-status
+func wasmexport_OfflineTaskStatus(ctx0 uint32, taskID0 *uint8, taskID1 uint32) (result *cm.Result[OfflineTaskShape, OfflineTask, DriverErrors]) {
+	ctx := cm.Reinterpret[cm.Rep]((uint32)(ctx0))
+	taskID := cm.LiftString[string]((*uint8)(taskID0), (uint32)(taskID1))
+	result_ := Exports.OfflineTaskStatus(ctx, taskID)
+	result = &result_
+	return
+}
+
+//go:wasmexport openlist:plugin-driver/exports@0.1.0#offline-task-cancel
+//export openlist:plugin-driver/exports@0.1.0#offline-task-cancel
+func wasmexport_OfflineTaskCancel(ctx0 uint32, taskID0 *uint8, taskID1 uint32) (result *cm.Result[DriverErrors, struct{}, DriverErrors]) {
+	ctx := cm.Reinterpret[cm.Rep]((uint32)(ctx0))
+	taskID := cm.LiftString[string]((*uint8)(taskID0), (uint32)(taskID1))
+	result_ := Exports.OfflineTaskCancel(ctx, taskID)
+	result = &result_
+	return
+}
+
+//go:wasmexport openlist:plugin-driver/exports@0.1.0#archive-meta
+//export openlist:plugin-driver/exports@0.1.0#archive-meta
+func wasmexport_ArchiveMeta(params *wasmexport_ArchiveMeta_params) (result *cm.Result[ArchiveMetaShape, ArchiveMeta, DriverErrors]) {
+	result_ := Exports.ArchiveMeta(params.ctx, params.file, params.password)
+	result = &result_
+	return
+}
+
+//go:wasmexport openlist:plugin-driver/exports@0.1.0#list-archive
+//export openlist:plugin-driver/exports@0.1.0#list-archive
+func wasmexport_ListArchive(params *wasmexport_ListArchive_params) (result *cm.Result[DriverErrorsShape, cm.List[Object], DriverErrors]) {
+	result_ := Exports.ListArchive(params.ctx, params.file, params.args)
+	result = &result_
+	return
+}
+
+//go:wasmexport openlist:plugin-driver/exports@0.1.0#link-archive-entry
+//export openlist:plugin-driver/exports@0.1.0#link-archive-entry
+func wasmexport_LinkArchiveEntry(params *wasmexport_LinkArchiveEntry_params) (result *cm.Result[LinkResultShape, LinkResult, DriverErrors]) {
+	result_ := Exports.LinkArchiveEntry(params.ctx, params.file, params.args, params.link)
+	result = &result_
+	return
+}
+
+//go:wasmexport openlist:plugin-driver/exports@0.1.0#extract-archive
+//export openlist:plugin-driver/exports@0.1.0#extract-archive
+func wasmexport_ExtractArchive(params *wasmexport_ExtractArchive_params) (result *cm.Result[DriverErrorsShape, cm.List[Object], DriverErrors]) {
+	result_ := Exports.ExtractArchive(params.ctx, params.file, params.args, params.toDir)
+	result = &result_
+	return
+}
+
+//go:wasmexport openlist:plugin-driver/exports@0.1.0#list-trash
+//export openlist:plugin-driver/exports@0.1.0#list-trash
+func wasmexport_ListTrash(ctx0 uint32) (result *cm.Result[DriverErrorsShape, cm.List[TrashedObject], DriverErrors]) {
+	ctx := cm.Reinterpret[cm.Rep]((uint32)(ctx0))
+	result_ := Exports.ListTrash(ctx)
+	result = &result_
+	return
+}
+
+//go:wasmexport openlist:plugin-driver/exports@0.1.0#restore-file
+//export openlist:plugin-driver/exports@0.1.0#restore-file
+func wasmexport_RestoreFile(params *wasmexport_RestoreFile_params) (result *cm.Result[OptionObjectShape, cm.Option[Object], DriverErrors]) {
+	result_ := Exports.RestoreFile(params.ctx, params.file)
+	result = &result_
+	return
+}
+
+//go:wasmexport openlist:plugin-driver/exports@0.1.0#purge-file
+//export openlist:plugin-driver/exports@0.1.0#purge-file
+func wasmexport_PurgeFile(params *wasmexport_PurgeFile_params) (result *cm.Result[DriverErrors, struct{}, DriverErrors]) {
+	result_ := Exports.PurgeFile(params.ctx, params.file)
+	result = &result_
+	return
+}
+
+//go:wasmexport openlist:plugin-driver/exports@0.1.0#empty-trash
+//export openlist:plugin-driver/exports@0.1.0#empty-trash
+func wasmexport_EmptyTrash(ctx0 uint32) (result *cm.Result[DriverErrors, struct{}, DriverErrors]) {
+	ctx := cm.Reinterpret[cm.Rep]((uint32)(ctx0))
+	result_ := Exports.EmptyTrash(ctx)
+	result = &result_
+	return
+}
+
+//go:wasmexport openlist:plugin-driver/exports@0.1.0#list-versions
+//export openlist:plugin-driver/exports@0.1.0#list-versions
+func wasmexport_ListVersions(params *wasmexport_ListVersions_params) (result *cm.Result[DriverErrorsShape, cm.List[FileVersion], DriverErrors]) {
+	result_ := Exports.ListVersions(params.ctx, params.file)
+	result = &result_
+	return
+}
+
+//go:wasmexport openlist:plugin-driver/exports@0.1.0#link-version
+//export openlist:plugin-driver/exports@0.1.0#link-version
+func wasmexport_LinkVersion(params *wasmexport_LinkVersion_params) (result *cm.Result[LinkResultShape, LinkResult, DriverErrors]) {
+	result_ := Exports.LinkVersion(params.ctx, params.file, params.versionID, params.args)
+	result = &result_
+	return
+}
+
+//go:wasmexport openlist:plugin-driver/exports@0.1.0#restore-version
+//export openlist:plugin-driver/exports@0.1.0#restore-version
+func wasmexport_RestoreVersion(params *wasmexport_RestoreVersion_params) (result *cm.Result[OptionObjectShape, cm.Option[Object], DriverErrors]) {
+	result_ := Exports.RestoreVersion(params.ctx, params.file, params.versionID)
+	result = &result_
+	return
+}
+
+//go:wasmexport openlist:plugin-driver/exports@0.1.0#create-share
+//export openlist:plugin-driver/exports@0.1.0#create-share
+func wasmexport_CreateShare(params *wasmexport_CreateShare_params) (result *cm.Result[ShareInfoShape, ShareInfo, DriverErrors]) {
+	result_ := Exports.CreateShare(params.ctx, params.file, params.options)
+	result = &result_
+	return
+}
+
+//go:wasmexport openlist:plugin-driver/exports@0.1.0#list-shares
+//export openlist:plugin-driver/exports@0.1.0#list-shares
+func wasmexport_ListShares(params *wasmexport_ListShares_params) (result *cm.Result[DriverErrorsShape, cm.List[ShareInfo], DriverErrors]) {
+	result_ := Exports.ListShares(params.ctx, params.file)
+	result = &result_
+	return
+}
+
+//go:wasmexport openlist:plugin-driver/exports@0.1.0#revoke-share
+//export openlist:plugin-driver/exports@0.1.0#revoke-share
+func wasmexport_RevokeShare(ctx0 uint32, shareID0 *uint8, shareID1 uint32) (result *cm.Result[DriverErrors, struct{}, DriverErrors]) {
+	ctx := cm.Reinterpret[cm.Rep]((uint32)(ctx0))
+	shareID := cm.LiftString[string]((*uint8)(shareID0), (uint32)(shareID1))
+	result_ := Exports.RevokeShare(ctx, shareID)
+	result = &result_
+	return
+}
+
+//go:wasmexport openlist:plugin-driver/exports@0.1.0#other
+//export openlist:plugin-driver/exports@0.1.0#other
+func wasmexport_Other(params *wasmexport_Other_params) (result *cm.Result[DriverErrorsShape, cm.List[uint8], DriverErrors]) {
+	result_ := Exports.Other(params.ctx, params.file, params.method, params.jsonArgs)
+	result = &result_
+	return
+}
@@ -39,6 +39,71 @@ type Capability = types.Capability
 // See [types.Object] for more information.
 type Object = types.Object
 
+// ListPage represents the type alias "openlist:plugin-driver/exports@0.1.0#list-page".
+//
+// See [types.ListPage] for more information.
+type ListPage = types.ListPage
+
+// SearchScope represents the type alias "openlist:plugin-driver/exports@0.1.0#search-scope".
+//
+// See [types.SearchScope] for more information.
+type SearchScope = types.SearchScope
+
+// SearchPage represents the type alias "openlist:plugin-driver/exports@0.1.0#search-page".
+//
+// See [types.SearchPage] for more information.
+type SearchPage = types.SearchPage
+
+// StorageDetails represents the type alias "openlist:plugin-driver/exports@0.1.0#storage-details".
+//
+// See [types.StorageDetails] for more information.
+type StorageDetails = types.StorageDetails
+
+// OfflineTask represents the type alias "openlist:plugin-driver/exports@0.1.0#offline-task".
+//
+// See [types.OfflineTask] for more information.
+type OfflineTask = types.OfflineTask
+
+// ArchiveArgs represents the type alias "openlist:plugin-driver/exports@0.1.0#archive-args".
+//
+// See [types.ArchiveArgs] for more information.
+type ArchiveArgs = types.ArchiveArgs
+
+// ArchiveMeta represents the type alias "openlist:plugin-driver/exports@0.1.0#archive-meta".
+//
+// See [types.ArchiveMeta] for more information.
+type ArchiveMeta = types.ArchiveMeta
+
+// TrashedObject represents the type alias "openlist:plugin-driver/exports@0.1.0#trashed-object".
+//
+// See [types.TrashedObject] for more information.
+type TrashedObject = types.TrashedObject
+
+// FileVersion represents the type alias "openlist:plugin-driver/exports@0.1.0#file-version".
+//
+// See [types.FileVersion] for more information.
+type FileVersion = types.FileVersion
+
+// ShareOptions represents the type alias "openlist:plugin-driver/exports@0.1.0#share-options".
+//
+// See [types.ShareOptions] for more information.
+type ShareOptions = types.ShareOptions
+
+// ShareInfo represents the type alias "openlist:plugin-driver/exports@0.1.0#share-info".
+//
+// See [types.ShareInfo] for more information.
+type ShareInfo = types.ShareInfo
+
+// UploadLinkInfo represents the type alias "openlist:plugin-driver/exports@0.1.0#upload-link-info".
+//
+// See [types.UploadLinkInfo] for more information.
+type UploadLinkInfo = types.UploadLinkInfo
+
+// CompletedPart represents the type alias "openlist:plugin-driver/exports@0.1.0#completed-part".
+//
+// See [types.CompletedPart] for more information.
+type CompletedPart = types.CompletedPart
+
 // RangeSpec represents the exported type alias "openlist:plugin-driver/exports@0.1.0#range-spec".
 //
 // See [types.RangeSpec] for more information.
@@ -77,6 +142,27 @@ type wasmexport_ListFiles_params struct {
 	dir Object        `json:"dir"`
 }
 
+// wasmexport_ListFilesPage_params represents the flattened function params for [wasmexport_ListFilesPage].
+// See the Canonical ABI flattening rules for more information.
+type wasmexport_ListFilesPage_params struct {
+	_      cm.HostLayout     `json:"-"`
+	ctx    cm.Rep            `json:"ctx"`
+	dir    Object            `json:"dir"`
+	cursor cm.Option[string] `json:"cursor"`
+	limit  uint32            `json:"limit"`
+}
+
+// wasmexport_Search_params represents the flattened function params for [wasmexport_Search].
+// See the Canonical ABI flattening rules for more information.
+type wasmexport_Search_params struct {
+	_        cm.HostLayout `json:"-"`
+	ctx      cm.Rep        `json:"ctx"`
+	dir      Object        `json:"dir"`
+	keywords string        `json:"keywords"`
+	scope    SearchScope   `json:"scope"`
+	page     SearchPage    `json:"page"`
+}
+
 // wasmexport_LinkFile_params represents the flattened function params for [wasmexport_LinkFile].
 // See the Canonical ABI flattening rules for more information.
 type wasmexport_LinkFile_params struct {
@@ -148,3 +234,150 @@ type wasmexport_UploadFile_params struct {
 	dir Object        `json:"dir"`
 	req UploadRequest `json:"req"`
 }
+
+// wasmexport_UploadURL_params represents the flattened function params for [wasmexport_UploadURL].
+// See the Canonical ABI flattening rules for more information.
+type wasmexport_UploadURL_params struct {
+	_    cm.HostLayout `json:"-"`
+	ctx  cm.Rep        `json:"ctx"`
+	dir  Object        `json:"dir"`
+	name string        `json:"name"`
+	url  string        `json:"url"`
+}
+
+// wasmexport_UploadLink_params represents the flattened function params for [wasmexport_UploadLink].
+// See the Canonical ABI flattening rules for more information.
+type wasmexport_UploadLink_params struct {
+	_    cm.HostLayout `json:"-"`
+	ctx  cm.Rep        `json:"ctx"`
+	dir  Object        `json:"dir"`
+	file Object        `json:"file"`
+}
+
+// wasmexport_CompleteUpload_params represents the flattened function params for [wasmexport_CompleteUpload].
+// See the Canonical ABI flattening rules for more information.
+type wasmexport_CompleteUpload_params struct {
+	_        cm.HostLayout          `json:"-"`
+	ctx      cm.Rep                 `json:"ctx"`
+	dir      Object                 `json:"dir"`
+	file     Object                 `json:"file"`
+	uploadID string                 `json:"upload-id"`
+	parts    cm.List[CompletedPart] `json:"parts"`
+}
+
+// wasmexport_OfflineDownload_params represents the flattened function params for [wasmexport_OfflineDownload].
+// See the Canonical ABI flattening rules for more information.
+type wasmexport_OfflineDownload_params struct {
+	_     cm.HostLayout `json:"-"`
+	ctx   cm.Rep        `json:"ctx"`
+	url   string        `json:"url"`
+	toDir Object        `json:"to-dir"`
+}
+
+// wasmexport_ArchiveMeta_params represents the flattened function params for [wasmexport_ArchiveMeta].
+// See the Canonical ABI flattening rules for more information.
+type wasmexport_ArchiveMeta_params struct {
+	_        cm.HostLayout `json:"-"`
+	ctx      cm.Rep        `json:"ctx"`
+	file     Object        `json:"file"`
+	password string        `json:"password"`
+}
+
+// wasmexport_ListArchive_params represents the flattened function params for [wasmexport_ListArchive].
+// See the Canonical ABI flattening rules for more information.
+type wasmexport_ListArchive_params struct {
+	_    cm.HostLayout `json:"-"`
+	ctx  cm.Rep        `json:"ctx"`
+	file Object        `json:"file"`
+	args ArchiveArgs   `json:"args"`
+}
+
+// wasmexport_LinkArchiveEntry_params represents the flattened function params for [wasmexport_LinkArchiveEntry].
+// See the Canonical ABI flattening rules for more information.
+type wasmexport_LinkArchiveEntry_params struct {
+	_    cm.HostLayout `json:"-"`
+	ctx  cm.Rep        `json:"ctx"`
+	file Object        `json:"file"`
+	args ArchiveArgs   `json:"args"`
+	link LinkArgs      `json:"link"`
+}
+
+// wasmexport_ExtractArchive_params represents the flattened function params for [wasmexport_ExtractArchive].
+// See the Canonical ABI flattening rules for more information.
+type wasmexport_ExtractArchive_params struct {
+	_     cm.HostLayout `json:"-"`
+	ctx   cm.Rep        `json:"ctx"`
+	file  Object        `json:"file"`
+	args  ArchiveArgs   `json:"args"`
+	toDir Object        `json:"to-dir"`
+}
+
+// wasmexport_RestoreFile_params represents the flattened function params for [wasmexport_RestoreFile].
+// See the Canonical ABI flattening rules for more information.
+type wasmexport_RestoreFile_params struct {
+	_    cm.HostLayout `json:"-"`
+	ctx  cm.Rep        `json:"ctx"`
+	file Object        `json:"file"`
+}
+
+// wasmexport_PurgeFile_params represents the flattened function params for [wasmexport_PurgeFile].
+// See the Canonical ABI flattening rules for more information.
+type wasmexport_PurgeFile_params struct {
+	_    cm.HostLayout `json:"-"`
+	ctx  cm.Rep        `json:"ctx"`
+	file Object        `json:"file"`
+}
+
+// wasmexport_ListVersions_params represents the flattened function params for [wasmexport_ListVersions].
+// See the Canonical ABI flattening rules for more information.
+type wasmexport_ListVersions_params struct {
+	_    cm.HostLayout `json:"-"`
+	ctx  cm.Rep        `json:"ctx"`
+	file Object        `json:"file"`
+}
+
+// wasmexport_LinkVersion_params represents the flattened function params for [wasmexport_LinkVersion].
+// See the Canonical ABI flattening rules for more information.
+type wasmexport_LinkVersion_params struct {
+	_         cm.HostLayout `json:"-"`
+	ctx       cm.Rep        `json:"ctx"`
+	file      Object        `json:"file"`
+	versionID string        `json:"version-id"`
+	args      LinkArgs      `json:"args"`
+}
+
+// wasmexport_RestoreVersion_params represents the flattened function params for [wasmexport_RestoreVersion].
+// See the Canonical ABI flattening rules for more information.
+type wasmexport_RestoreVersion_params struct {
+	_         cm.HostLayout `json:"-"`
+	ctx       cm.Rep        `json:"ctx"`
+	file      Object        `json:"file"`
+	versionID string        `json:"version-id"`
+}
+
+// wasmexport_CreateShare_params represents the flattened function params for [wasmexport_CreateShare].
+// See the Canonical ABI flattening rules for more information.
+type wasmexport_CreateShare_params struct {
+	_       cm.HostLayout `json:"-"`
+	ctx     cm.Rep        `json:"ctx"`
+	file    Object        `json:"file"`
+	options ShareOptions  `json:"options"`
+}
+
+// wasmexport_ListShares_params represents the flattened function params for [wasmexport_ListShares].
+// See the Canonical ABI flattening rules for more information.
+type wasmexport_ListShares_params struct {
+	_    cm.HostLayout     `json:"-"`
+	ctx  cm.Rep            `json:"ctx"`
+	file cm.Option[Object] `json:"file"`
+}
+
+// wasmexport_Other_params represents the flattened function params for [wasmexport_Other].
+// See the Canonical ABI flattening rules for more information.
+type wasmexport_Other_params struct {
+	_        cm.HostLayout  `json:"-"`
+	ctx      cm.Rep         `json:"ctx"`
+	file     Object         `json:"file"`
+	method   string         `json:"method"`
+	jsonArgs cm.List[uint8] `json:"json-args"`
+}
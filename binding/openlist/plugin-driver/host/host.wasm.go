@@ -19,3 +19,15 @@ func wasmimport_LoadConfig(handle0 uint32, result *cm.Result[cm.List[uint8], cm.
 //go:wasmimport openlist:plugin-driver/host@0.1.0 save-config
 //go:noescape
 func wasmimport_SaveConfig(handle0 uint32, config0 *uint8, config1 uint32, result *cm.Result[string, struct{}, string])
+
+//go:wasmimport openlist:plugin-driver/host@0.1.0 load-upload-session
+//go:noescape
+func wasmimport_LoadUploadSession(handle0 uint32, path0 *uint8, path1 uint32, hash0 *uint8, hash1 uint32, result *cm.Result[OptionListU8Shape, cm.Option[cm.List[uint8]], string])
+
+//go:wasmimport openlist:plugin-driver/host@0.1.0 save-upload-session
+//go:noescape
+func wasmimport_SaveUploadSession(handle0 uint32, path0 *uint8, path1 uint32, hash0 *uint8, hash1 uint32, state0 *uint8, state1 uint32, result *cm.Result[string, struct{}, string])
+
+//go:wasmimport openlist:plugin-driver/host@0.1.0 remove-upload-session
+//go:noescape
+func wasmimport_RemoveUploadSession(handle0 uint32, path0 *uint8, path1 uint32, hash0 *uint8, hash1 uint32, result *cm.Result[string, struct{}, string])
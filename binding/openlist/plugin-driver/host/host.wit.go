@@ -92,3 +92,52 @@ func SaveConfig(handle uint32, config cm.List[uint8]) (result cm.Result[string,
 	wasmimport_SaveConfig((uint32)(handle0), (*uint8)(config0), (uint32)(config1), &result)
 	return
 }
+
+// LoadUploadSession represents the imported function "load-upload-session".
+//
+// 读取上传会话状态，以目标路径与内容哈希为键，不存在时返回 none。
+//
+//	load-upload-session: func(handle: u32, path: string, hash: string) -> result<option<list<u8>>,
+//	string>
+//
+//go:nosplit
+func LoadUploadSession(handle uint32, path string, hash string) (result cm.Result[OptionListU8Shape, cm.Option[cm.List[uint8]], string]) {
+	handle0 := (uint32)(handle)
+	path0, path1 := cm.LowerString(path)
+	hash0, hash1 := cm.LowerString(hash)
+	wasmimport_LoadUploadSession((uint32)(handle0), (*uint8)(path0), (uint32)(path1), (*uint8)(hash0), (uint32)(hash1), &result)
+	return
+}
+
+// SaveUploadSession represents the imported function "save-upload-session".
+//
+// 保存上传会话状态，宿主重启后仍需能够读取。
+//
+//	save-upload-session: func(handle: u32, path: string, hash: string, state: list<u8>)
+//	-> result<_, string>
+//
+//go:nosplit
+func SaveUploadSession(handle uint32, path string, hash string, state cm.List[uint8]) (result cm.Result[string, struct{}, string]) {
+	handle0 := (uint32)(handle)
+	path0, path1 := cm.LowerString(path)
+	hash0, hash1 := cm.LowerString(hash)
+	state0, state1 := cm.LowerList(state)
+	wasmimport_SaveUploadSession((uint32)(handle0), (*uint8)(path0), (uint32)(path1), (*uint8)(hash0), (uint32)(hash1), (*uint8)(state0), (uint32)(state1), &result)
+	return
+}
+
+// RemoveUploadSession represents the imported function "remove-upload-session".
+//
+// 删除上传会话状态，上传完成或放弃时调用。
+//
+//	remove-upload-session: func(handle: u32, path: string, hash: string) -> result<_,
+//	string>
+//
+//go:nosplit
+func RemoveUploadSession(handle uint32, path string, hash string) (result cm.Result[string, struct{}, string]) {
+	handle0 := (uint32)(handle)
+	path0, path1 := cm.LowerString(path)
+	hash0, hash1 := cm.LowerString(hash)
+	wasmimport_RemoveUploadSession((uint32)(handle0), (*uint8)(path0), (uint32)(path1), (*uint8)(hash0), (uint32)(hash1), &result)
+	return
+}
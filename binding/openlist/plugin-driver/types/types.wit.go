@@ -418,8 +418,20 @@ type FormField struct {
 //		remove-file,
 //		copy-file,
 //		upload-file,
+//		list-file-page,
+//		search-file,
+//		get-details,
+//		offline-download,
+//		archive-read,
+//		archive-decompress,
+//		trash,
+//		version,
+//		share,
+//		other,
+//		upload-url,
+//		upload-link,
 //	}
-type Capability uint16
+type Capability uint32
 
 const (
 	CapabilityGetFile Capability = 1 << iota
@@ -431,6 +443,42 @@ const (
 	CapabilityRemoveFile
 	CapabilityCopyFile
 	CapabilityUploadFile
+
+	// 支持分页列举
+	CapabilityListFilePage
+
+	// 支持服务端搜索
+	CapabilitySearchFile
+
+	// 支持获取存储空间详情
+	CapabilityGetDetails
+
+	// 支持离线下载
+	CapabilityOfflineDownload
+
+	// 支持浏览压缩包
+	CapabilityArchiveRead
+
+	// 支持服务端解压
+	CapabilityArchiveDecompress
+
+	// 支持回收站，remove-file 会将对象移入回收站而非彻底删除
+	CapabilityTrash
+
+	// 支持历史版本
+	CapabilityVersion
+
+	// 支持创建分享链接
+	CapabilityShare
+
+	// 支持驱动自定义操作
+	CapabilityOther
+
+	// 支持通过 url 上传
+	CapabilityUploadURL
+
+	// 支持预签名直传
+	CapabilityUploadLink
 )
 
 // DriverProps represents the record "openlist:plugin-driver/types@0.1.0#driver-props".
@@ -562,40 +610,154 @@ func (v LinkResource) String() string {
 //		sha1,
 //		sha256,
 //		gcid,
+//		crc32c,
+//		crc64-ecma,
+//		quick-xor,
+//		dropbox,
+//		xxh3,
+//		blake3,
+//		other(string),
 //	}
-type HashAlg uint8
+type HashAlg cm.Variant[uint8, string, string]
 
-const (
-	HashAlgMd5 HashAlg = iota
-	HashAlgSha1
-	HashAlgSha256
-	HashAlgGcid
-)
+// HashAlgMd5 returns a [HashAlg] of case "md5".
+func HashAlgMd5() HashAlg {
+	var data struct{}
+	return cm.New[HashAlg](0, data)
+}
 
-var _HashAlgStrings = [4]string{
-	"md5",
-	"sha1",
-	"sha256",
-	"gcid",
+// Md5 returns true if [HashAlg] represents the variant case "md5".
+func (self *HashAlg) Md5() bool {
+	return self.Tag() == 0
 }
 
-// String implements [fmt.Stringer], returning the enum case name of e.
-func (e HashAlg) String() string {
-	return _HashAlgStrings[e]
+// HashAlgSha1 returns a [HashAlg] of case "sha1".
+func HashAlgSha1() HashAlg {
+	var data struct{}
+	return cm.New[HashAlg](1, data)
 }
 
-// MarshalText implements [encoding.TextMarshaler].
-func (e HashAlg) MarshalText() ([]byte, error) {
-	return []byte(e.String()), nil
+// Sha1 returns true if [HashAlg] represents the variant case "sha1".
+func (self *HashAlg) Sha1() bool {
+	return self.Tag() == 1
 }
 
-// UnmarshalText implements [encoding.TextUnmarshaler], unmarshaling into an enum
-// case. Returns an error if the supplied text is not one of the enum cases.
-func (e *HashAlg) UnmarshalText(text []byte) error {
-	return _HashAlgUnmarshalCase(e, text)
+// HashAlgSha256 returns a [HashAlg] of case "sha256".
+func HashAlgSha256() HashAlg {
+	var data struct{}
+	return cm.New[HashAlg](2, data)
+}
+
+// Sha256 returns true if [HashAlg] represents the variant case "sha256".
+func (self *HashAlg) Sha256() bool {
+	return self.Tag() == 2
+}
+
+// HashAlgGcid returns a [HashAlg] of case "gcid".
+func HashAlgGcid() HashAlg {
+	var data struct{}
+	return cm.New[HashAlg](3, data)
+}
+
+// Gcid returns true if [HashAlg] represents the variant case "gcid".
+func (self *HashAlg) Gcid() bool {
+	return self.Tag() == 3
+}
+
+// HashAlgCrc32c returns a [HashAlg] of case "crc32c".
+func HashAlgCrc32c() HashAlg {
+	var data struct{}
+	return cm.New[HashAlg](4, data)
+}
+
+// Crc32c returns true if [HashAlg] represents the variant case "crc32c".
+func (self *HashAlg) Crc32c() bool {
+	return self.Tag() == 4
+}
+
+// HashAlgCrc64Ecma returns a [HashAlg] of case "crc64-ecma".
+func HashAlgCrc64Ecma() HashAlg {
+	var data struct{}
+	return cm.New[HashAlg](5, data)
+}
+
+// Crc64Ecma returns true if [HashAlg] represents the variant case "crc64-ecma".
+func (self *HashAlg) Crc64Ecma() bool {
+	return self.Tag() == 5
+}
+
+// HashAlgQuickXor returns a [HashAlg] of case "quick-xor".
+func HashAlgQuickXor() HashAlg {
+	var data struct{}
+	return cm.New[HashAlg](6, data)
+}
+
+// QuickXor returns true if [HashAlg] represents the variant case "quick-xor".
+func (self *HashAlg) QuickXor() bool {
+	return self.Tag() == 6
+}
+
+// HashAlgDropbox returns a [HashAlg] of case "dropbox".
+func HashAlgDropbox() HashAlg {
+	var data struct{}
+	return cm.New[HashAlg](7, data)
+}
+
+// Dropbox returns true if [HashAlg] represents the variant case "dropbox".
+func (self *HashAlg) Dropbox() bool {
+	return self.Tag() == 7
+}
+
+// HashAlgXxh3 returns a [HashAlg] of case "xxh3".
+func HashAlgXxh3() HashAlg {
+	var data struct{}
+	return cm.New[HashAlg](8, data)
 }
 
-var _HashAlgUnmarshalCase = cm.CaseUnmarshaler[HashAlg](_HashAlgStrings[:])
+// Xxh3 returns true if [HashAlg] represents the variant case "xxh3".
+func (self *HashAlg) Xxh3() bool {
+	return self.Tag() == 8
+}
+
+// HashAlgBlake3 returns a [HashAlg] of case "blake3".
+func HashAlgBlake3() HashAlg {
+	var data struct{}
+	return cm.New[HashAlg](9, data)
+}
+
+// Blake3 returns true if [HashAlg] represents the variant case "blake3".
+func (self *HashAlg) Blake3() bool {
+	return self.Tag() == 9
+}
+
+// HashAlgOther returns a [HashAlg] of case "other".
+func HashAlgOther(data string) HashAlg {
+	return cm.New[HashAlg](10, data)
+}
+
+// Other returns a non-nil *[string] if [HashAlg] represents the variant case "other".
+func (self *HashAlg) Other() *string {
+	return cm.Case[string](self, 10)
+}
+
+var _HashAlgStrings = [11]string{
+	"md5",
+	"sha1",
+	"sha256",
+	"gcid",
+	"crc32c",
+	"crc64-ecma",
+	"quick-xor",
+	"dropbox",
+	"xxh3",
+	"blake3",
+	"other",
+}
+
+// String implements [fmt.Stringer], returning the variant case name of v.
+func (v HashAlg) String() string {
+	return _HashAlgStrings[v.Tag()]
+}
 
 // HashInfo represents the record "openlist:plugin-driver/types@0.1.0#hash-info".
 //
@@ -661,6 +823,468 @@ type Object struct {
 	Extra cm.List[[2]string] `json:"extra"`
 }
 
+// ListPage represents the record "openlist:plugin-driver/types@0.1.0#list-page".
+//
+// 分页列举的结果。
+//
+//	record list-page {
+//		objects: list<object>,
+//		next-cursor: option<string>,
+//	}
+type ListPage struct {
+	_ cm.HostLayout `json:"-"`
+	// 当前页的对象。
+	Objects cm.List[Object] `json:"objects"`
+
+	// 下一页的游标，为 none 时表示没有更多数据。
+	NextCursor cm.Option[string] `json:"next-cursor"`
+}
+
+// SearchScope represents the enum "openlist:plugin-driver/types@0.1.0#search-scope".
+//
+// 搜索的对象范围。
+//
+//	enum search-scope {
+//		all,
+//		folder,
+//		file
+//	}
+type SearchScope uint8
+
+const (
+	// 文件和目录
+	SearchScopeAll SearchScope = iota
+
+	// 仅目录
+	SearchScopeFolder
+
+	// 仅文件
+	SearchScopeFile
+)
+
+var _SearchScopeStrings = [3]string{
+	"all",
+	"folder",
+	"file",
+}
+
+// String implements [fmt.Stringer], returning the enum case name of e.
+func (e SearchScope) String() string {
+	return _SearchScopeStrings[e]
+}
+
+// MarshalText implements [encoding.TextMarshaler].
+func (e SearchScope) MarshalText() ([]byte, error) {
+	return []byte(e.String()), nil
+}
+
+// UnmarshalText implements [encoding.TextUnmarshaler], unmarshaling into an enum
+// case. Returns an error if the supplied text is not one of the enum cases.
+func (e *SearchScope) UnmarshalText(text []byte) error {
+	return _SearchScopeUnmarshalCase(e, text)
+}
+
+var _SearchScopeUnmarshalCase = cm.CaseUnmarshaler[SearchScope](_SearchScopeStrings[:])
+
+// SearchPage represents the record "openlist:plugin-driver/types@0.1.0#search-page".
+//
+// 搜索的分页参数。
+//
+//	record search-page {
+//		page: u32,
+//		per-page: u32,
+//	}
+type SearchPage struct {
+	_ cm.HostLayout `json:"-"`
+	// 页码，从 1 开始
+	Page uint32 `json:"page"`
+
+	// 每页数量
+	PerPage uint32 `json:"per-page"`
+}
+
+// ObjectKind represents the enum "openlist:plugin-driver/types@0.1.0#object-kind".
+//
+// 对象的分类，用于统计存储用量。
+//
+//	enum object-kind {
+//		folder,
+//		video,
+//		audio,
+//		image,
+//		text,
+//		office,
+//		archive,
+//		other
+//	}
+type ObjectKind uint8
+
+const (
+	ObjectKindFolder ObjectKind = iota
+	ObjectKindVideo
+	ObjectKindAudio
+	ObjectKindImage
+	ObjectKindText
+	ObjectKindOffice
+	ObjectKindArchive
+	ObjectKindOther
+)
+
+var _ObjectKindStrings = [8]string{
+	"folder",
+	"video",
+	"audio",
+	"image",
+	"text",
+	"office",
+	"archive",
+	"other",
+}
+
+// String implements [fmt.Stringer], returning the enum case name of e.
+func (e ObjectKind) String() string {
+	return _ObjectKindStrings[e]
+}
+
+// MarshalText implements [encoding.TextMarshaler].
+func (e ObjectKind) MarshalText() ([]byte, error) {
+	return []byte(e.String()), nil
+}
+
+// UnmarshalText implements [encoding.TextUnmarshaler], unmarshaling into an enum
+// case. Returns an error if the supplied text is not one of the enum cases.
+func (e *ObjectKind) UnmarshalText(text []byte) error {
+	return _ObjectKindUnmarshalCase(e, text)
+}
+
+var _ObjectKindUnmarshalCase = cm.CaseUnmarshaler[ObjectKind](_ObjectKindStrings[:])
+
+// KindUsage represents the record "openlist:plugin-driver/types@0.1.0#kind-usage".
+//
+// 某一类对象占用的空间。
+//
+//	record kind-usage {
+//		kind: object-kind,
+//		size: u64,
+//		count: u64,
+//	}
+type KindUsage struct {
+	_    cm.HostLayout `json:"-"`
+	Kind ObjectKind    `json:"kind"`
+
+	// 占用空间（字节）
+	Size uint64 `json:"size"`
+
+	// 对象数量
+	Count uint64 `json:"count"`
+}
+
+// StorageDetails represents the record "openlist:plugin-driver/types@0.1.0#storage-details".
+//
+// 存储空间详情。
+//
+//	record storage-details {
+//		total: u64,
+//		used: u64,
+//		free: u64,
+//		breakdown: option<list<kind-usage>>,
+//	}
+type StorageDetails struct {
+	_ cm.HostLayout `json:"-"`
+	// 总空间（字节）
+	Total uint64 `json:"total"`
+
+	// 已用空间（字节）
+	Used uint64 `json:"used"`
+
+	// 剩余空间（字节）
+	Free uint64 `json:"free"`
+
+	// 按对象分类的用量，驱动不支持时为 none
+	Breakdown cm.Option[cm.List[KindUsage]] `json:"breakdown"`
+}
+
+// TaskStatus represents the enum "openlist:plugin-driver/types@0.1.0#task-status".
+//
+// 离线下载任务的状态。
+//
+//	enum task-status {
+//		pending,
+//		running,
+//		succeeded,
+//		failed,
+//		canceled
+//	}
+type TaskStatus uint8
+
+const (
+	TaskStatusPending TaskStatus = iota
+	TaskStatusRunning
+	TaskStatusSucceeded
+	TaskStatusFailed
+	TaskStatusCanceled
+)
+
+var _TaskStatusStrings = [5]string{
+	"pending",
+	"running",
+	"succeeded",
+	"failed",
+	"canceled",
+}
+
+// String implements [fmt.Stringer], returning the enum case name of e.
+func (e TaskStatus) String() string {
+	return _TaskStatusStrings[e]
+}
+
+// MarshalText implements [encoding.TextMarshaler].
+func (e TaskStatus) MarshalText() ([]byte, error) {
+	return []byte(e.String()), nil
+}
+
+// UnmarshalText implements [encoding.TextUnmarshaler], unmarshaling into an enum
+// case. Returns an error if the supplied text is not one of the enum cases.
+func (e *TaskStatus) UnmarshalText(text []byte) error {
+	return _TaskStatusUnmarshalCase(e, text)
+}
+
+var _TaskStatusUnmarshalCase = cm.CaseUnmarshaler[TaskStatus](_TaskStatusStrings[:])
+
+// OfflineTask represents the record "openlist:plugin-driver/types@0.1.0#offline-task".
+//
+// 离线下载任务。
+//
+//	record offline-task {
+//		id: string,
+//		name: string,
+//		status: task-status,
+//		progress: f64,
+//		size: option<u64>,
+//		message: option<string>,
+//	}
+type OfflineTask struct {
+	_ cm.HostLayout `json:"-"`
+	// 任务id，用于查询与取消
+	ID string `json:"id"`
+
+	// 任务名称，通常为下载的文件名
+	Name   string     `json:"name"`
+	Status TaskStatus `json:"status"`
+
+	// 下载进度[0,100]
+	Progress float64 `json:"progress"`
+
+	// 文件大小（字节），未知时为 none
+	Size cm.Option[uint64] `json:"size"`
+
+	// 任务失败时的原因
+	Message cm.Option[string] `json:"message"`
+}
+
+// ArchiveArgs represents the record "openlist:plugin-driver/types@0.1.0#archive-args".
+//
+// 压缩包内操作的参数。
+//
+//	record archive-args {
+//		inner-path: string,
+//		password: string,
+//	}
+type ArchiveArgs struct {
+	_ cm.HostLayout `json:"-"`
+	// 压缩包内的路径，根目录为 "/"
+	InnerPath string `json:"inner-path"`
+
+	// 解压密码，无密码时为空
+	Password string `json:"password"`
+}
+
+// ArchiveMeta represents the record "openlist:plugin-driver/types@0.1.0#archive-meta".
+//
+// 压缩包的元信息。
+//
+//	record archive-meta {
+//		comment: string,
+//		encrypted: bool,
+//		tree: option<list<object>>,
+//	}
+type ArchiveMeta struct {
+	_ cm.HostLayout `json:"-"`
+	// 压缩包注释
+	Comment string `json:"comment"`
+
+	// 是否加密
+	Encrypted bool `json:"encrypted"`
+
+	// 压缩包内的完整目录树，object 的 path 为包内路径
+	// 为 none 时宿主通过 list-archive 逐级列举
+	Tree cm.Option[cm.List[Object]] `json:"tree"`
+}
+
+// TrashedObject represents the record "openlist:plugin-driver/types@0.1.0#trashed-object".
+//
+// 回收站中的对象。
+//
+//	record trashed-object {
+//		object: object,
+//		original-path: string,
+//		deleted: duration,
+//		expires: option<duration>,
+//	}
+type TrashedObject struct {
+	_ cm.HostLayout `json:"-"`
+	// 对象在回收站中的信息，宿主使用它调用 restore-file 与 purge-file
+	Object Object `json:"object"`
+
+	// 删除前的原始路径
+	OriginalPath string `json:"original-path"`
+
+	// 删除时间戳
+	Deleted Duration `json:"deleted"`
+
+	// 自动清除的时间戳，为 none 时不会自动清除
+	Expires cm.Option[Duration] `json:"expires"`
+}
+
+// FileVersion represents the record "openlist:plugin-driver/types@0.1.0#file-version".
+//
+// 文件的历史版本。
+//
+//	record file-version {
+//		id: string,
+//		size: s64,
+//		modified: duration,
+//		hashes: list<hash-info>,
+//	}
+type FileVersion struct {
+	_ cm.HostLayout `json:"-"`
+	// 版本id
+	ID string `json:"id"`
+
+	// 该版本的大小（字节）
+	Size int64 `json:"size"`
+
+	// 该版本的修改时间戳
+	Modified Duration `json:"modified"`
+
+	// 该版本的哈希信息列表
+	Hashes cm.List[HashInfo] `json:"hashes"`
+}
+
+// ShareOptions represents the record "openlist:plugin-driver/types@0.1.0#share-options".
+//
+// 创建分享的参数。
+//
+//	record share-options {
+//		password: option<string>,
+//		expiration: option<duration>,
+//	}
+type ShareOptions struct {
+	_ cm.HostLayout `json:"-"`
+	// 提取码，为 none 时创建公开分享
+	Password cm.Option[string] `json:"password"`
+
+	// 有效时长，为 none 时永久有效
+	Expiration cm.Option[Duration] `json:"expiration"`
+}
+
+// ShareInfo represents the record "openlist:plugin-driver/types@0.1.0#share-info".
+//
+// 分享链接的信息。
+//
+//	record share-info {
+//		id: string,
+//		url: string,
+//		password: option<string>,
+//		expires: option<duration>,
+//		file: object,
+//	}
+type ShareInfo struct {
+	_ cm.HostLayout `json:"-"`
+	// 分享id，用于撤销分享
+	ID string `json:"id"`
+
+	// 分享链接
+	URL string `json:"url"`
+
+	// 提取码
+	Password cm.Option[string] `json:"password"`
+
+	// 过期时间戳，为 none 时永久有效
+	Expires cm.Option[Duration] `json:"expires"`
+
+	// 被分享的对象
+	File Object `json:"file"`
+}
+
+// UploadPartLink represents the record "openlist:plugin-driver/types@0.1.0#upload-part-link".
+//
+// 预签名上传的单个分块。
+//
+//	record upload-part-link {
+//		part-number: u32,
+//		offset: u64,
+//		size: u64,
+//		url: string,
+//		method: string,
+//		headers: headers,
+//		expiration: option<duration>,
+//	}
+type UploadPartLink struct {
+	_ cm.HostLayout `json:"-"`
+	// 分块序号，从 1 开始
+	PartNumber uint32 `json:"part-number"`
+
+	// 分块在文件中的偏移（字节）
+	Offset uint64 `json:"offset"`
+
+	// 分块大小（字节）
+	Size uint64 `json:"size"`
+
+	// 上传地址
+	URL string `json:"url"`
+
+	// 请求方法，例如 PUT
+	Method string `json:"method"`
+
+	// 请求需要携带的头
+	Headers Headers `json:"headers"`
+
+	// 有效时长，为 none 时永久有效
+	Expiration cm.Option[Duration] `json:"expiration"`
+}
+
+// UploadLinkInfo represents the record "openlist:plugin-driver/types@0.1.0#upload-link-info".
+//
+// 预签名上传的信息，宿主按分块直接上传内容。
+//
+//	record upload-link-info {
+//		upload-id: string,
+//		parts: list<upload-part-link>,
+//	}
+type UploadLinkInfo struct {
+	_ cm.HostLayout `json:"-"`
+	// 服务端的上传id，complete-upload 时原样传回
+	UploadID string                  `json:"upload-id"`
+	Parts    cm.List[UploadPartLink] `json:"parts"`
+}
+
+// CompletedPart represents the record "openlist:plugin-driver/types@0.1.0#completed-part".
+//
+// 宿主已上传完成的分块。
+//
+//	record completed-part {
+//		part-number: u32,
+//		etag: string,
+//	}
+type CompletedPart struct {
+	_          cm.HostLayout `json:"-"`
+	PartNumber uint32        `json:"part-number"`
+
+	// 服务端返回的分块标识，例如 S3 的 ETag
+	Etag string `json:"etag"`
+}
+
 // UploadRequest represents the imported record "openlist:plugin-driver/types@0.1.0#upload-request".
 //
 // 封装上传操作的所有参数。
@@ -31,6 +31,23 @@ type Reader interface {
 	LinkFile(ctx context.Context, file drivertypes.Object, args LinkArgs) (*drivertypes.LinkResource, *drivertypes.Object, error)
 }
 
+// 用于优化大目录的List，按页返回对象
+// 未实现时 list-files-page 会回退到 ListFiles 并在插件内分页
+type ListPager interface {
+	// cursor 为空表示第一页，返回的 nextCursor 为空表示没有更多数据
+	ListFilesPage(ctx context.Context, dir drivertypes.Object, cursor string, limit uint32) (objs []drivertypes.Object, nextCursor string, err error)
+}
+
+// 用于服务端搜索，返回的 Object 必须填写完整的 Path
+type Searcher interface {
+	Search(ctx context.Context, dir drivertypes.Object, keywords string, scope drivertypes.SearchScope, page drivertypes.SearchPage) ([]drivertypes.Object, error)
+}
+
+// 用于获取存储空间的总量与用量
+type Detailer interface {
+	GetDetails(ctx context.Context) (*drivertypes.StorageDetails, error)
+}
+
 type StreamReader interface {
 	LinkRange(ctx context.Context, file drivertypes.Object, args LinkArgs, _range drivertypes.RangeSpec, w io.WriteCloser) error
 }
@@ -63,6 +80,71 @@ type Put interface {
 	Put(ctx context.Context, dstDir drivertypes.Object, file adapter.UploadRequest) (*drivertypes.Object, error)
 }
 
+// 用于由网盘服务端直接从 url 获取文件
+type PutURL interface {
+	PutURL(ctx context.Context, dstDir drivertypes.Object, name, url string) (*drivertypes.Object, error)
+}
+
+// 用于预签名直传，宿主直接将内容上传到返回的分块地址，不经过插件
+// 分块的 headers 可通过 adapter.NewHeaders 构造
+type UploadLinker interface {
+	// file 描述待上传的文件，包括名称、大小和已知的哈希
+	UploadLink(ctx context.Context, dstDir drivertypes.Object, file drivertypes.Object) (*drivertypes.UploadLinkInfo, error)
+	// 宿主上传完所有分块后调用，uploadID 为 UploadLink 返回的值
+	CompleteUpload(ctx context.Context, dstDir drivertypes.Object, file drivertypes.Object, uploadID string, parts []drivertypes.CompletedPart) (*drivertypes.Object, error)
+}
+
+// 用于网盘服务端的离线下载
+type OfflineDownloader interface {
+	// 提交离线下载任务，将 url 下载到 dstDir
+	OfflineDownload(ctx context.Context, url string, dstDir drivertypes.Object) (*drivertypes.OfflineTask, error)
+	OfflineTaskStatus(ctx context.Context, taskID string) (*drivertypes.OfflineTask, error)
+	CancelOfflineTask(ctx context.Context, taskID string) error
+}
+
+// 用于浏览压缩包，由网盘服务端解析压缩包内容
+type ArchiveReader interface {
+	GetArchiveMeta(ctx context.Context, file drivertypes.Object, password string) (*drivertypes.ArchiveMeta, error)
+	// 返回的 Object 的 Path 为压缩包内路径
+	ListArchive(ctx context.Context, file drivertypes.Object, args drivertypes.ArchiveArgs) ([]drivertypes.Object, error)
+	LinkArchiveEntry(ctx context.Context, file drivertypes.Object, args drivertypes.ArchiveArgs, link LinkArgs) (*drivertypes.LinkResource, *drivertypes.Object, error)
+}
+
+// 用于服务端解压
+type ArchiveDecompressor interface {
+	ExtractArchive(ctx context.Context, file drivertypes.Object, args drivertypes.ArchiveArgs, dstDir drivertypes.Object) ([]drivertypes.Object, error)
+}
+
+// 用于回收站管理，实现后 Remove 应将对象移入回收站
+type Trash interface {
+	ListTrash(ctx context.Context) ([]drivertypes.TrashedObject, error)
+	RestoreFile(ctx context.Context, obj drivertypes.Object) (*drivertypes.Object, error)
+	PurgeFile(ctx context.Context, obj drivertypes.Object) error
+	EmptyTrash(ctx context.Context) error
+}
+
+// 用于访问与回滚文件的历史版本
+type Versioner interface {
+	ListVersions(ctx context.Context, file drivertypes.Object) ([]drivertypes.FileVersion, error)
+	LinkVersion(ctx context.Context, file drivertypes.Object, versionID string, args LinkArgs) (*drivertypes.LinkResource, *drivertypes.Object, error)
+	RestoreVersion(ctx context.Context, file drivertypes.Object, versionID string) (*drivertypes.Object, error)
+}
+
+// 用于创建与管理分享链接
+type Sharer interface {
+	CreateShare(ctx context.Context, file drivertypes.Object, opts drivertypes.ShareOptions) (*drivertypes.ShareInfo, error)
+	// file 为 nil 时列举全部分享
+	ListShares(ctx context.Context, file *drivertypes.Object) ([]drivertypes.ShareInfo, error)
+	RevokeShare(ctx context.Context, shareID string) error
+}
+
+// 用于驱动特定的操作，对应 OpenList 的 /fs/other
+// 可嵌入 adapter.OtherMux 按 method 注册带类型参数的处理函数
+type Other interface {
+	// 返回值会被编码为 JSON
+	Other(ctx context.Context, args adapter.OtherArgs) (any, error)
+}
+
 func RegisterDriver(driver Driver) {
 	exports.Exports.SetHandle = func(handle uint32) {
 		hostHeadle = handle
@@ -85,6 +167,21 @@ func RegisterDriver(driver Driver) {
 				flags |= drivertypes.CapabilityListFile
 			}
 
+			// 检查是否实现 ListPager 接口
+			if _, ok := driver.(ListPager); ok {
+				flags |= drivertypes.CapabilityListFilePage
+			}
+
+			// 检查是否实现 Searcher 接口
+			if _, ok := driver.(Searcher); ok {
+				flags |= drivertypes.CapabilitySearchFile
+			}
+
+			// 检查是否实现 Detailer 接口
+			if _, ok := driver.(Detailer); ok {
+				flags |= drivertypes.CapabilityGetDetails
+			}
+
 			// 检查是否实现 Mkdir 接口
 			if _, ok := driver.(Mkdir); ok {
 				flags |= drivertypes.CapabilityMkdirFile
@@ -114,6 +211,51 @@ func RegisterDriver(driver Driver) {
 			if _, ok := driver.(Put); ok {
 				flags |= drivertypes.CapabilityUploadFile
 			}
+
+			// 检查是否实现 PutURL 接口
+			if _, ok := driver.(PutURL); ok {
+				flags |= drivertypes.CapabilityUploadURL
+			}
+
+			// 检查是否实现 UploadLinker 接口
+			if _, ok := driver.(UploadLinker); ok {
+				flags |= drivertypes.CapabilityUploadLink
+			}
+
+			// 检查是否实现 OfflineDownloader 接口
+			if _, ok := driver.(OfflineDownloader); ok {
+				flags |= drivertypes.CapabilityOfflineDownload
+			}
+
+			// 检查是否实现 ArchiveReader 接口
+			if _, ok := driver.(ArchiveReader); ok {
+				flags |= drivertypes.CapabilityArchiveRead
+			}
+
+			// 检查是否实现 ArchiveDecompressor 接口
+			if _, ok := driver.(ArchiveDecompressor); ok {
+				flags |= drivertypes.CapabilityArchiveDecompress
+			}
+
+			// 检查是否实现 Trash 接口
+			if _, ok := driver.(Trash); ok {
+				flags |= drivertypes.CapabilityTrash
+			}
+
+			// 检查是否实现 Versioner 接口
+			if _, ok := driver.(Versioner); ok {
+				flags |= drivertypes.CapabilityVersion
+			}
+
+			// 检查是否实现 Sharer 接口
+			if _, ok := driver.(Sharer); ok {
+				flags |= drivertypes.CapabilityShare
+			}
+
+			// 检查是否实现 Other 接口
+			if _, ok := driver.(Other); ok {
+				flags |= drivertypes.CapabilityOther
+			}
 			properties.Capabilitys = flags
 		}
 
@@ -168,6 +310,25 @@ func RegisterDriver(driver Driver) {
 		return cm.OK[adapter.ResultObject](*root)
 	}
 
+	exports.Exports.GetDetails = func(pctx cm.Rep) (result adapter.ResultStorageDetails) {
+		driver, ok := driver.(Detailer)
+		if !ok {
+			return cm.Err[adapter.ResultStorageDetails](drivertypes.DriverErrorsNotImplemented())
+		}
+
+		ctx, cancel := adapter.WarpCancellable(pctx)
+		defer cancel()
+		details, err := driver.GetDetails(ctx)
+		if err != nil {
+			return cm.Err[adapter.ResultStorageDetails](adapter.ErrorToDriverError(err))
+		}
+		// 驱动只提供总量和用量时补全剩余空间
+		if details.Free == 0 && details.Total > details.Used {
+			details.Free = details.Total - details.Used
+		}
+		return cm.OK[adapter.ResultStorageDetails](*details)
+	}
+
 	exports.Exports.ListFiles = func(pctx cm.Rep, dir exports.Object) (result adapter.ResultObjects) {
 		// driver, ok := _driver.(Reader)
 		// if !ok {
@@ -183,6 +344,45 @@ func RegisterDriver(driver Driver) {
 		return adapter.ReturnOkObjects(objs)
 	}
 
+	// 未实现 ListPager 时翻页使用的缓存
+	var listPageCache adapter.ListPageCache
+	exports.Exports.ListFilesPage = func(pctx cm.Rep, dir exports.Object, cursor cm.Option[string], limit uint32) (result adapter.ResultListPage) {
+		ctx, cancel := adapter.WarpCancellable(pctx)
+		defer cancel()
+
+		if driver, ok := driver.(ListPager); ok {
+			objs, next, err := driver.ListFilesPage(ctx, dir, cursor.Value(), limit)
+			if err != nil {
+				return cm.Err[adapter.ResultListPage](adapter.ErrorToDriverError(err))
+			}
+			return adapter.ReturnOkListPage(objs, next)
+		}
+
+		// 回退到 ListFiles，完整列表在翻页期间缓存在插件内
+		objs, next, err := listPageCache.Page(dir.Path, cursor.Value(), limit, func() ([]drivertypes.Object, error) {
+			return driver.ListFiles(ctx, dir)
+		})
+		if err != nil {
+			return cm.Err[adapter.ResultListPage](adapter.ErrorToDriverError(err))
+		}
+		return adapter.ReturnOkListPage(objs, next)
+	}
+
+	exports.Exports.Search = func(pctx cm.Rep, dir exports.Object, keywords string, scope exports.SearchScope, page exports.SearchPage) (result adapter.ResultObjects) {
+		driver, ok := driver.(Searcher)
+		if !ok {
+			return cm.Err[adapter.ResultObjects](drivertypes.DriverErrorsNotImplemented())
+		}
+
+		ctx, cancel := adapter.WarpCancellable(pctx)
+		defer cancel()
+		objs, err := driver.Search(ctx, dir, keywords, scope, page)
+		if err != nil {
+			return cm.Err[adapter.ResultObjects](adapter.ErrorToDriverError(err))
+		}
+		return adapter.ReturnOkObjects(objs)
+	}
+
 	exports.Exports.LinkFile = func(pctx cm.Rep, file exports.Object, args exports.LinkArgs) (result cm.Result[exports.LinkResultShape, exports.LinkResult, exports.DriverErrors]) {
 		// driver, ok := _driver.(Reader)
 		// if !ok {
@@ -210,7 +410,7 @@ func RegisterDriver(driver Driver) {
 			return cm.Err[cm.Result[exports.DriverErrors, struct{}, exports.DriverErrors]](drivertypes.DriverErrorsNotImplemented())
 		}
 
-		stream := adapter.NewOutputStream(range_.Stream)
+		stream := adapter.NewOutputStreamContext(ctx, range_.Stream)
 		err := driver.LinkRange(ctx, file, args, range_, &stream)
 		if err != nil {
 			return cm.Err[cm.Result[exports.DriverErrors, struct{}, exports.DriverErrors]](adapter.ErrorToDriverError(err))
@@ -304,10 +504,337 @@ func RegisterDriver(driver Driver) {
 		ctx, cancel := adapter.WarpCancellable(pctx)
 		defer cancel()
 
-		obj, err := driver.Put(ctx, dir, adapter.UploadRequest{UploadRequest: req})
+		obj, err := driver.Put(ctx, dir, adapter.NewUploadRequest(ctx, req))
+		if err != nil {
+			return cm.Err[adapter.ResultOptionObject](adapter.ErrorToDriverError(err))
+		}
+		return adapter.ReturnOkOptionObject(obj)
+	}
+
+	exports.Exports.UploadURL = func(pctx cm.Rep, dir exports.Object, name string, url string) (result adapter.ResultOptionObject) {
+		driver, ok := driver.(PutURL)
+		if !ok {
+			return cm.Err[adapter.ResultOptionObject](drivertypes.DriverErrorsNotImplemented())
+		}
+
+		ctx, cancel := adapter.WarpCancellable(pctx)
+		defer cancel()
+		obj, err := driver.PutURL(ctx, dir, name, url)
+		if err != nil {
+			return cm.Err[adapter.ResultOptionObject](adapter.ErrorToDriverError(err))
+		}
+		return adapter.ReturnOkOptionObject(obj)
+	}
+
+	exports.Exports.UploadLink = func(pctx cm.Rep, dir exports.Object, file exports.Object) (result adapter.ResultUploadLinkInfo) {
+		driver, ok := driver.(UploadLinker)
+		if !ok {
+			return cm.Err[adapter.ResultUploadLinkInfo](drivertypes.DriverErrorsNotImplemented())
+		}
+
+		ctx, cancel := adapter.WarpCancellable(pctx)
+		defer cancel()
+		info, err := driver.UploadLink(ctx, dir, file)
+		if err != nil {
+			return cm.Err[adapter.ResultUploadLinkInfo](adapter.ErrorToDriverError(err))
+		}
+		return cm.OK[adapter.ResultUploadLinkInfo](*info)
+	}
+
+	exports.Exports.CompleteUpload = func(pctx cm.Rep, dir exports.Object, file exports.Object, uploadID string, parts cm.List[exports.CompletedPart]) (result adapter.ResultOptionObject) {
+		driver, ok := driver.(UploadLinker)
+		if !ok {
+			return cm.Err[adapter.ResultOptionObject](drivertypes.DriverErrorsNotImplemented())
+		}
+
+		ctx, cancel := adapter.WarpCancellable(pctx)
+		defer cancel()
+		obj, err := driver.CompleteUpload(ctx, dir, file, uploadID, parts.Slice())
+		if err != nil {
+			return cm.Err[adapter.ResultOptionObject](adapter.ErrorToDriverError(err))
+		}
+		return adapter.ReturnOkOptionObject(obj)
+	}
+
+	exports.Exports.OfflineDownload = func(pctx cm.Rep, url string, toDir exports.Object) (result adapter.ResultOfflineTask) {
+		driver, ok := driver.(OfflineDownloader)
+		if !ok {
+			return cm.Err[adapter.ResultOfflineTask](drivertypes.DriverErrorsNotImplemented())
+		}
+
+		ctx, cancel := adapter.WarpCancellable(pctx)
+		defer cancel()
+		task, err := driver.OfflineDownload(ctx, url, toDir)
+		if err != nil {
+			return cm.Err[adapter.ResultOfflineTask](adapter.ErrorToDriverError(err))
+		}
+		return cm.OK[adapter.ResultOfflineTask](*task)
+	}
+
+	exports.Exports.OfflineTaskStatus = func(pctx cm.Rep, taskID string) (result adapter.ResultOfflineTask) {
+		driver, ok := driver.(OfflineDownloader)
+		if !ok {
+			return cm.Err[adapter.ResultOfflineTask](drivertypes.DriverErrorsNotImplemented())
+		}
+
+		ctx, cancel := adapter.WarpCancellable(pctx)
+		defer cancel()
+		task, err := driver.OfflineTaskStatus(ctx, taskID)
+		if err != nil {
+			return cm.Err[adapter.ResultOfflineTask](adapter.ErrorToDriverError(err))
+		}
+		return cm.OK[adapter.ResultOfflineTask](*task)
+	}
+
+	exports.Exports.OfflineTaskCancel = func(pctx cm.Rep, taskID string) (result adapter.Result) {
+		driver, ok := driver.(OfflineDownloader)
+		if !ok {
+			return cm.Err[adapter.Result](drivertypes.DriverErrorsNotImplemented())
+		}
+
+		ctx, cancel := adapter.WarpCancellable(pctx)
+		defer cancel()
+		if err := driver.CancelOfflineTask(ctx, taskID); err != nil {
+			return cm.Err[adapter.Result](adapter.ErrorToDriverError(err))
+		}
+		return adapter.ReturnOk()
+	}
+
+	exports.Exports.ArchiveMeta = func(pctx cm.Rep, file exports.Object, password string) (result adapter.ResultArchiveMeta) {
+		driver, ok := driver.(ArchiveReader)
+		if !ok {
+			return cm.Err[adapter.ResultArchiveMeta](drivertypes.DriverErrorsNotImplemented())
+		}
+
+		ctx, cancel := adapter.WarpCancellable(pctx)
+		defer cancel()
+		meta, err := driver.GetArchiveMeta(ctx, file, password)
+		if err != nil {
+			return cm.Err[adapter.ResultArchiveMeta](adapter.ErrorToDriverError(err))
+		}
+		return cm.OK[adapter.ResultArchiveMeta](*meta)
+	}
+
+	exports.Exports.ListArchive = func(pctx cm.Rep, file exports.Object, args exports.ArchiveArgs) (result adapter.ResultObjects) {
+		driver, ok := driver.(ArchiveReader)
+		if !ok {
+			return cm.Err[adapter.ResultObjects](drivertypes.DriverErrorsNotImplemented())
+		}
+
+		ctx, cancel := adapter.WarpCancellable(pctx)
+		defer cancel()
+		objs, err := driver.ListArchive(ctx, file, args)
+		if err != nil {
+			return cm.Err[adapter.ResultObjects](adapter.ErrorToDriverError(err))
+		}
+		return adapter.ReturnOkObjects(objs)
+	}
+
+	exports.Exports.LinkArchiveEntry = func(pctx cm.Rep, file exports.Object, args exports.ArchiveArgs, link exports.LinkArgs) (result cm.Result[exports.LinkResultShape, exports.LinkResult, exports.DriverErrors]) {
+		driver, ok := driver.(ArchiveReader)
+		if !ok {
+			return cm.Err[cm.Result[exports.LinkResultShape, exports.LinkResult, exports.DriverErrors]](drivertypes.DriverErrorsNotImplemented())
+		}
+
+		ctx, cancel := adapter.WarpCancellable(pctx)
+		defer cancel()
+		res, newFile, err := driver.LinkArchiveEntry(ctx, file, args, link)
+		if err != nil {
+			return cm.Err[cm.Result[exports.LinkResultShape, exports.LinkResult, exports.DriverErrors]](adapter.ErrorToDriverError(err))
+		}
+
+		return cm.OK[cm.Result[exports.LinkResultShape, exports.LinkResult, exports.DriverErrors]](exports.LinkResult{
+			File:     adapter.OptionObject(newFile),
+			Resource: *res,
+		})
+	}
+
+	exports.Exports.ExtractArchive = func(pctx cm.Rep, file exports.Object, args exports.ArchiveArgs, toDir exports.Object) (result adapter.ResultObjects) {
+		driver, ok := driver.(ArchiveDecompressor)
+		if !ok {
+			return cm.Err[adapter.ResultObjects](drivertypes.DriverErrorsNotImplemented())
+		}
+
+		ctx, cancel := adapter.WarpCancellable(pctx)
+		defer cancel()
+		objs, err := driver.ExtractArchive(ctx, file, args, toDir)
+		if err != nil {
+			return cm.Err[adapter.ResultObjects](adapter.ErrorToDriverError(err))
+		}
+		return adapter.ReturnOkObjects(objs)
+	}
+
+	exports.Exports.ListTrash = func(pctx cm.Rep) (result adapter.ResultTrashedObjects) {
+		driver, ok := driver.(Trash)
+		if !ok {
+			return cm.Err[adapter.ResultTrashedObjects](drivertypes.DriverErrorsNotImplemented())
+		}
+
+		ctx, cancel := adapter.WarpCancellable(pctx)
+		defer cancel()
+		objs, err := driver.ListTrash(ctx)
+		if err != nil {
+			return cm.Err[adapter.ResultTrashedObjects](adapter.ErrorToDriverError(err))
+		}
+		return cm.OK[adapter.ResultTrashedObjects](cm.ToList(objs))
+	}
+
+	exports.Exports.RestoreFile = func(pctx cm.Rep, file exports.Object) (result adapter.ResultOptionObject) {
+		driver, ok := driver.(Trash)
+		if !ok {
+			return cm.Err[adapter.ResultOptionObject](drivertypes.DriverErrorsNotImplemented())
+		}
+
+		ctx, cancel := adapter.WarpCancellable(pctx)
+		defer cancel()
+		obj, err := driver.RestoreFile(ctx, file)
+		if err != nil {
+			return cm.Err[adapter.ResultOptionObject](adapter.ErrorToDriverError(err))
+		}
+		return adapter.ReturnOkOptionObject(obj)
+	}
+
+	exports.Exports.PurgeFile = func(pctx cm.Rep, file exports.Object) (result adapter.Result) {
+		driver, ok := driver.(Trash)
+		if !ok {
+			return cm.Err[adapter.Result](drivertypes.DriverErrorsNotImplemented())
+		}
+
+		ctx, cancel := adapter.WarpCancellable(pctx)
+		defer cancel()
+		if err := driver.PurgeFile(ctx, file); err != nil {
+			return cm.Err[adapter.Result](adapter.ErrorToDriverError(err))
+		}
+		return adapter.ReturnOk()
+	}
+
+	exports.Exports.EmptyTrash = func(pctx cm.Rep) (result adapter.Result) {
+		driver, ok := driver.(Trash)
+		if !ok {
+			return cm.Err[adapter.Result](drivertypes.DriverErrorsNotImplemented())
+		}
+
+		ctx, cancel := adapter.WarpCancellable(pctx)
+		defer cancel()
+		if err := driver.EmptyTrash(ctx); err != nil {
+			return cm.Err[adapter.Result](adapter.ErrorToDriverError(err))
+		}
+		return adapter.ReturnOk()
+	}
+
+	exports.Exports.ListVersions = func(pctx cm.Rep, file exports.Object) (result adapter.ResultFileVersions) {
+		driver, ok := driver.(Versioner)
+		if !ok {
+			return cm.Err[adapter.ResultFileVersions](drivertypes.DriverErrorsNotImplemented())
+		}
+
+		ctx, cancel := adapter.WarpCancellable(pctx)
+		defer cancel()
+		versions, err := driver.ListVersions(ctx, file)
+		if err != nil {
+			return cm.Err[adapter.ResultFileVersions](adapter.ErrorToDriverError(err))
+		}
+		return cm.OK[adapter.ResultFileVersions](cm.ToList(versions))
+	}
+
+	exports.Exports.LinkVersion = func(pctx cm.Rep, file exports.Object, versionID string, args exports.LinkArgs) (result cm.Result[exports.LinkResultShape, exports.LinkResult, exports.DriverErrors]) {
+		driver, ok := driver.(Versioner)
+		if !ok {
+			return cm.Err[cm.Result[exports.LinkResultShape, exports.LinkResult, exports.DriverErrors]](drivertypes.DriverErrorsNotImplemented())
+		}
+
+		ctx, cancel := adapter.WarpCancellable(pctx)
+		defer cancel()
+		link, newFile, err := driver.LinkVersion(ctx, file, versionID, args)
+		if err != nil {
+			return cm.Err[cm.Result[exports.LinkResultShape, exports.LinkResult, exports.DriverErrors]](adapter.ErrorToDriverError(err))
+		}
+
+		return cm.OK[cm.Result[exports.LinkResultShape, exports.LinkResult, exports.DriverErrors]](exports.LinkResult{
+			File:     adapter.OptionObject(newFile),
+			Resource: *link,
+		})
+	}
+
+	exports.Exports.RestoreVersion = func(pctx cm.Rep, file exports.Object, versionID string) (result adapter.ResultOptionObject) {
+		driver, ok := driver.(Versioner)
+		if !ok {
+			return cm.Err[adapter.ResultOptionObject](drivertypes.DriverErrorsNotImplemented())
+		}
+
+		ctx, cancel := adapter.WarpCancellable(pctx)
+		defer cancel()
+		obj, err := driver.RestoreVersion(ctx, file, versionID)
 		if err != nil {
 			return cm.Err[adapter.ResultOptionObject](adapter.ErrorToDriverError(err))
 		}
 		return adapter.ReturnOkOptionObject(obj)
 	}
+
+	exports.Exports.CreateShare = func(pctx cm.Rep, file exports.Object, options exports.ShareOptions) (result adapter.ResultShareInfo) {
+		driver, ok := driver.(Sharer)
+		if !ok {
+			return cm.Err[adapter.ResultShareInfo](drivertypes.DriverErrorsNotImplemented())
+		}
+
+		ctx, cancel := adapter.WarpCancellable(pctx)
+		defer cancel()
+		share, err := driver.CreateShare(ctx, file, options)
+		if err != nil {
+			return cm.Err[adapter.ResultShareInfo](adapter.ErrorToDriverError(err))
+		}
+		return cm.OK[adapter.ResultShareInfo](*share)
+	}
+
+	exports.Exports.ListShares = func(pctx cm.Rep, file cm.Option[exports.Object]) (result adapter.ResultShareInfos) {
+		driver, ok := driver.(Sharer)
+		if !ok {
+			return cm.Err[adapter.ResultShareInfos](drivertypes.DriverErrorsNotImplemented())
+		}
+
+		ctx, cancel := adapter.WarpCancellable(pctx)
+		defer cancel()
+		shares, err := driver.ListShares(ctx, file.Some())
+		if err != nil {
+			return cm.Err[adapter.ResultShareInfos](adapter.ErrorToDriverError(err))
+		}
+		return cm.OK[adapter.ResultShareInfos](cm.ToList(shares))
+	}
+
+	exports.Exports.RevokeShare = func(pctx cm.Rep, shareID string) (result adapter.Result) {
+		driver, ok := driver.(Sharer)
+		if !ok {
+			return cm.Err[adapter.Result](drivertypes.DriverErrorsNotImplemented())
+		}
+
+		ctx, cancel := adapter.WarpCancellable(pctx)
+		defer cancel()
+		if err := driver.RevokeShare(ctx, shareID); err != nil {
+			return cm.Err[adapter.Result](adapter.ErrorToDriverError(err))
+		}
+		return adapter.ReturnOk()
+	}
+
+	exports.Exports.Other = func(pctx cm.Rep, file exports.Object, method string, jsonArgs cm.List[uint8]) (result adapter.ResultBytes) {
+		driver, ok := driver.(Other)
+		if !ok {
+			return cm.Err[adapter.ResultBytes](drivertypes.DriverErrorsNotImplemented())
+		}
+
+		ctx, cancel := adapter.WarpCancellable(pctx)
+		defer cancel()
+		resp, err := driver.Other(ctx, adapter.OtherArgs{
+			Obj:    file,
+			Method: method,
+			Data:   jsonArgs.Slice(),
+		})
+		if err != nil {
+			return cm.Err[adapter.ResultBytes](adapter.ErrorToDriverError(err))
+		}
+		data, err := adapter.MarshalOtherResult(resp)
+		if err != nil {
+			return cm.Err[adapter.ResultBytes](adapter.ErrorToDriverError(err))
+		}
+		return cm.OK[adapter.ResultBytes](cm.ToList(data))
+	}
 }
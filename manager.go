@@ -50,6 +50,19 @@ func (c DriverHandle) SaveConfig(val any) error {
 	return SaveConfig(val)
 }
 
+// DriverHandle 实现了 adapter.UploadSessionStore，可直接用于 adapter.NewResumableUploader
+func (c DriverHandle) LoadUploadSession(path, hash string) ([]byte, bool, error) {
+	return LoadUploadSession(path, hash)
+}
+
+func (c DriverHandle) SaveUploadSession(path, hash string, state []byte) error {
+	return SaveUploadSession(path, hash, state)
+}
+
+func (c DriverHandle) RemoveUploadSession(path, hash string) error {
+	return RemoveUploadSession(path, hash)
+}
+
 var hostHeadle uint32 = 0
 
 func LoadConfig(val any) error {
@@ -71,3 +84,34 @@ func SaveConfig(val any) error {
 	}
 	return nil
 }
+
+// LoadUploadSession 从宿主读取上传会话状态，不存在时返回 false
+func LoadUploadSession(path, hash string) ([]byte, bool, error) {
+	result := driverimports.LoadUploadSession(hostHeadle, path, hash)
+	if result.IsErr() {
+		return nil, false, errors.New(*result.Err())
+	}
+	state := result.OK().Some()
+	if state == nil {
+		return nil, false, nil
+	}
+	return state.Slice(), true, nil
+}
+
+// SaveUploadSession 请求宿主持久化上传会话状态
+func SaveUploadSession(path, hash string, state []byte) error {
+	result := driverimports.SaveUploadSession(hostHeadle, path, hash, cm.ToList(state))
+	if result.IsErr() {
+		return errors.New(*result.Err())
+	}
+	return nil
+}
+
+// RemoveUploadSession 请求宿主删除上传会话状态
+func RemoveUploadSession(path, hash string) error {
+	result := driverimports.RemoveUploadSession(hostHeadle, path, hash)
+	if result.IsErr() {
+		return errors.New(*result.Err())
+	}
+	return nil
+}